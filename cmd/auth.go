@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/auth"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect the credentials used for GitHub requests",
+	Long: `With --app-id, --app-installation-id and --app-private-key (or the app.*
+settings) requests are made as a GitHub App installation. Otherwise the token
+is taken from the first of these that is set:
+
+  --token flag
+  GITHUB_PROFILER_TOKEN, then GITHUB_TOKEN
+  token in the config file
+  GH_TOKEN (GH_ENTERPRISE_TOKEN for other hosts than github.com)
+  the gh CLI's hosts.yml
+  git credential helpers for the host
+  the Secret Service keyring (secret-tool), under service=` + auth.KeyringService + ` host=<host>
+  or the entry gh creates
+
+The host is github.com unless --api-url points elsewhere.`,
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which token is used, where it came from and its scopes",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authStatusCmd)
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	host := auth.HostFromAPIURL(apiURL)
+	if appConfigured() {
+		return runAppStatus(host)
+	}
+	discoverToken()
+	if githubToken == "" {
+		return fmt.Errorf("no token found for %s; see 'github-profiler auth --help' for where tokens are looked up", host)
+	}
+
+	fmt.Println(host)
+	fmt.Printf("  Token:   %s\n", auth.Mask(githubToken))
+	fmt.Printf("  Source:  %s\n", tokenSource)
+
+	service, err := newService()
+	if err != nil {
+		return err
+	}
+	info, err := service.TokenInfo(context.Background())
+	if err != nil {
+		return fmt.Errorf("token from %s was rejected: %w", tokenSource, err)
+	}
+
+	fmt.Printf("  Account: %s\n", info.Login)
+	switch {
+	case !info.ScopesReported:
+		fmt.Println("  Scopes:  not reported (fine-grained or GitHub App token)")
+	case len(info.Scopes) == 0:
+		fmt.Println("  Scopes:  none (public data only)")
+	default:
+		fmt.Printf("  Scopes:  %s\n", strings.Join(info.Scopes, ", "))
+	}
+	if info.RateKnown {
+		fmt.Printf("  Rate:    %d of %d requests left, resets %s\n",
+			info.RateRemaining, info.RateLimit, info.RateReset.Local().Format("15:04"))
+	}
+	return nil
+}
+
+// runAppStatus checks that an installation token can be minted for the App
+func runAppStatus(host string) error {
+	service, err := newService()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(host)
+	fmt.Printf("  Source:  %s\n", tokenSource)
+	info, err := service.TokenInfo(context.Background())
+	if err != nil {
+		return fmt.Errorf("GitHub App authentication failed: %w", err)
+	}
+
+	fmt.Println("  Token:   installation token, refreshed automatically")
+	if info.RateKnown {
+		fmt.Printf("  Rate:    %d of %d requests left, resets %s\n",
+			info.RateRemaining, info.RateLimit, info.RateReset.Local().Format("15:04"))
+	}
+	return nil
+}
+
+// appConfigured reports whether any GitHub App setting was given
+func appConfigured() bool {
+	return appID != 0 || appInstallationID != 0 || appPrivateKey != ""
+}
+
+// readAppPrivateKey returns the App's PEM private key. The setting holds either
+// a path or, as is common for secrets passed in the environment, the key itself.
+func readAppPrivateKey() ([]byte, error) {
+	if appID == 0 || appInstallationID == 0 || appPrivateKey == "" {
+		return nil, errors.New("GitHub App authentication needs --app-id, --app-installation-id and --app-private-key (or app.id, app.installation_id and app.private_key)")
+	}
+	if isPEM(appPrivateKey) {
+		return []byte(appPrivateKey), nil
+	}
+
+	data, err := os.ReadFile(appPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+	return data, nil
+}
+
+// isPEM reports whether value is PEM data rather than a path
+func isPEM(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN")
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/server"
+)
+
+var (
+	cardsAddr     string
+	cardsCacheTTL time.Duration
+)
+
+var cardsCmd = &cobra.Command{
+	Use:   "cards",
+	Short: "Serve SVG badges and stats cards for READMEs",
+	Long: `Runs an HTTP server rendering SVG images to embed in README files:
+
+  GET /badge/{login}.svg            ranking badge
+  GET /card/{login}.svg             stats card with top languages
+  GET /card/{login}/languages.svg   most used languages
+
+Query parameters:
+  theme=dark|light|high-contrast
+  hide=stars,forks,followers,repos,score,languages   (stats card)
+  hide=html,css                                      (languages card)
+  langs_count=8                                      (languages card, up to 10)
+  cache_seconds=3600   how long clients may cache the image, from --cache-ttl up to a day
+
+Profiles are fetched with your token and cached for --cache-ttl.`,
+	Args: cobra.NoArgs,
+	RunE: runCards,
+}
+
+func init() {
+	rootCmd.AddCommand(cardsCmd)
+	cardsCmd.Flags().StringVar(&cardsAddr, "addr", ":8080", "Address to listen on")
+	cardsCmd.Flags().DurationVar(&cardsCacheTTL, "cache-ttl", server.DefaultCacheTTL, "How long fetched profiles are served before fetching them again (0 disables the cache)")
+}
+
+func runCards(cmd *cobra.Command, args []string) error {
+	service, err := newService()
+	if err != nil {
+		return err
+	}
+
+	handler := server.New(service, server.WithCacheTTL(cardsCacheTTL)).CardHandler()
+	return listenAndServe(cardsAddr, handler, func() {
+		fmt.Fprintf(os.Stderr, "Serving README cards on %s\n", cardsAddr)
+	})
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and write settings in the config file",
+	Long: `Settings are layered: command line flags win over environment variables,
+which win over the config file. Each key can be overridden with a
+GITHUB_PROFILER_* variable, e.g. GITHUB_PROFILER_CACHE_TTL for cache.ttl;
+GITHUB_TOKEN is also read for the token.
+
+Keys: ` + strings.Join(config.Keys(), ", "),
+	// A broken config file must not stop these commands from fixing it
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the location of the config file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.DefaultPath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print a setting, or every setting, with environment overrides applied",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return err
+		}
+		if err := cfg.ApplyEnv(os.Getenv); err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			value, err := cfg.Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		}
+
+		for _, key := range config.Keys() {
+			value, _ := cfg.Get(key)
+			// Listing everything should not put secrets on screen
+			if key == "token" && value != "" || key == "app.private_key" && isPEM(value) {
+				value = "********"
+			}
+			fmt.Printf("%s: %s\n", key, value)
+		}
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: `Write a setting to the config file; "" unsets it`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.DefaultPath()
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Load(path)
+		if err != nil {
+			return err
+		}
+
+		if err := cfg.Set(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := config.Save(path, cfg); err != nil {
+			return err
+		}
+
+		if env := config.EnvVar(args[0]); os.Getenv(env) != "" {
+			fmt.Fprintf(os.Stderr, "Note: %s is set and overrides the config file\n", env)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/history"
+	"github-profiler/internal/output"
+)
+
+var (
+	diffSince   string
+	diffFormat  string
+	diffRefresh bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <username>",
+	Short: "Show how a profile changed over time",
+	Long: `Compares locally saved snapshots of a user's profile and reports the
+changes in stars, forks, followers, repositories, language share and ranking.
+
+Every profile fetched by github-profiler is saved as a snapshot. Use --refresh
+to take a fresh snapshot before comparing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffSince, "since", "90d", "Look-back window, e.g. 30d, 12w, 72h")
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format: text, json, html")
+	diffCmd.Flags().BoolVar(&diffRefresh, "refresh", false, "Fetch and save a fresh snapshot first")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	username := args[0]
+
+	window, err := history.ParseAge(diffSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since value: %w", err)
+	}
+
+	store, err := history.NewDefaultStore()
+	if err != nil {
+		return err
+	}
+
+	if diffRefresh {
+		service, err := newService()
+		if err != nil {
+			return err
+		}
+		profile, err := service.GetUserProfile(username)
+		if err != nil {
+			return err
+		}
+		if _, err := store.Save(profile); err != nil {
+			return err
+		}
+	}
+
+	snapshots, err := store.Since(username, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+
+	diff, err := history.Compare(snapshots)
+	if err != nil {
+		return fmt.Errorf("no history to compare for %s: %w", username, err)
+	}
+
+	switch diffFormat {
+	case "text":
+		return output.WriteDiffText(os.Stdout, diff)
+	case "json":
+		return output.WriteJSON(os.Stdout, diff)
+	case "html":
+		return output.WriteDiffHTML(os.Stdout, diff)
+	default:
+		return fmt.Errorf("unknown output format %q", diffFormat)
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/exporter"
+)
+
+var (
+	exporterAddr     string
+	exporterUsers    []string
+	exporterOrgs     []string
+	exporterInterval time.Duration
+)
+
+// Refreshes are sequential and profile every repository, so they are kept well apart
+const (
+	defaultExporterInterval = 15 * time.Minute
+	minExporterInterval     = time.Minute
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Export profile metrics for Prometheus",
+	Long: `Profiles a list of users and organizations on an interval and serves the
+results as Prometheus metrics on /metrics: stars, forks, followers, repository
+counts by type, language bytes and ranking scores per login, plus GitHub API
+request counts and rate limit gauges.
+
+The accounts come from --users and --orgs, or from the exporter section of the
+config file:
+
+  exporter:
+    users: [octocat, torvalds]
+    orgs: [github]
+    interval: 15m`,
+	Args: cobra.NoArgs,
+	RunE: runExporter,
+}
+
+func init() {
+	rootCmd.AddCommand(exporterCmd)
+	exporterCmd.Flags().StringVar(&exporterAddr, "addr", ":9101", "Address to listen on")
+	exporterCmd.Flags().StringSliceVar(&exporterUsers, "users", nil, "Users to profile (default from config)")
+	exporterCmd.Flags().StringSliceVar(&exporterOrgs, "orgs", nil, "Organizations to profile (default from config)")
+	exporterCmd.Flags().DurationVar(&exporterInterval, "interval", 0, "Time between refreshes (default from config, then 15m)")
+}
+
+func runExporter(cmd *cobra.Command, args []string) error {
+	// Flags replace the configured lists rather than adding to them
+	cfg := userConfig.Exporter
+	users, orgs, interval := cfg.Users, cfg.Orgs, cfg.Interval
+	if cmd.Flags().Changed("users") || cmd.Flags().Changed("orgs") {
+		users, orgs = exporterUsers, exporterOrgs
+	}
+	if exporterInterval > 0 {
+		interval = exporterInterval
+	}
+	if interval == 0 {
+		interval = defaultExporterInterval
+	}
+	if interval < minExporterInterval {
+		return fmt.Errorf("interval must be at least %s", minExporterInterval)
+	}
+
+	var targets []exporter.Target
+	for _, login := range users {
+		targets = append(targets, exporter.Target{Login: login})
+	}
+	for _, login := range orgs {
+		targets = append(targets, exporter.Target{Login: login, Org: true})
+	}
+	if len(targets) == 0 {
+		return errors.New("nothing to export: pass --users or --orgs, or list them under exporter in the config file")
+	}
+
+	service, err := newService()
+	if err != nil {
+		return err
+	}
+	exp := exporter.New(service, targets, interval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go exp.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", exp)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+
+	return listenAndServe(exporterAddr, mux, func() {
+		fmt.Fprintf(os.Stderr, "Exporting metrics for %d accounts every %s on %s/metrics\n", len(targets), interval, exporterAddr)
+	})
+}
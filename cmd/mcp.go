@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/mcp"
+	"github-profiler/internal/server"
+	"github-profiler/internal/services"
+)
+
+var mcpCacheTTL time.Duration
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve profiler tools to AI assistants over MCP (stdio)",
+	Long: `Runs a Model Context Protocol server on stdin and stdout so MCP clients
+can query GitHub profiles. The server offers these tools:
+
+  get_profile             profile summary, top languages and repositories
+  compare_profiles        side-by-side comparison of 2 to 10 users
+  get_language_breakdown  languages by bytes of code
+  explain_ranking         ranking rules fired and improvement hints
+
+Register it with a client by running 'github-profiler mcp' as a stdio server.
+Profiles are cached for --cache-ttl.`,
+	Args: cobra.NoArgs,
+	RunE: runMCP,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+	mcpCmd.Flags().DurationVar(&mcpCacheTTL, "cache-ttl", server.DefaultCacheTTL, "How long fetched profiles are served before fetching them again (0 disables the cache)")
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	discoverToken()
+	opts, err := serviceOptions()
+	if err != nil {
+		return err
+	}
+	// explain_ranking needs the explanation
+	opts = append(opts, services.WithExplanation())
+	service := services.NewGitHubService(githubToken, opts...)
+
+	// Reuse the API server for its cache and request coalescing
+	profiles := server.New(service, server.WithCacheTTL(mcpCacheTTL))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return mcp.NewServer(profiles, version).Serve(ctx, os.Stdin, os.Stdout)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/history"
+	"github-profiler/internal/output"
+	"github-profiler/internal/scoring"
+)
+
+var (
+	referenceOutput  string
+	referenceVersion string
+)
+
+var referenceCmd = &cobra.Command{
+	Use:   "reference",
+	Short: "Manage the reference score distribution used for percentiles",
+	Long: `Percentiles place a total score within a reference distribution of
+scores. Without --reference, the bundled distribution built with the active
+scoring rules is used; when none was, no percentile is reported. 'reference
+build' creates one from the locally stored profile snapshots, rescored with
+the active scoring model.`,
+}
+
+var referenceShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the active reference distribution",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if referenceFile != "" {
+			dist, err := scoring.LoadDistribution(referenceFile)
+			if err != nil {
+				return err
+			}
+			return output.WriteJSON(os.Stdout, dist)
+		}
+
+		service, err := newOfflineService()
+		if err != nil {
+			return err
+		}
+		dist := scoring.BundledDistribution(service.ScoringModel())
+		if dist == nil {
+			return errors.New("no bundled reference distribution matches the scoring rules; build one with 'reference build' and pass it with --reference")
+		}
+		return output.WriteJSON(os.Stdout, dist)
+	},
+}
+
+var referenceBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build a reference distribution from stored profile snapshots",
+	Args:  cobra.NoArgs,
+	RunE:  runReferenceBuild,
+}
+
+func init() {
+	rootCmd.AddCommand(referenceCmd)
+	referenceCmd.AddCommand(referenceShowCmd)
+	referenceCmd.AddCommand(referenceBuildCmd)
+	referenceBuildCmd.Flags().StringVarP(&referenceOutput, "output", "o", "", "Write the distribution to this file instead of stdout")
+	referenceBuildCmd.Flags().StringVar(&referenceVersion, "version", "", "Version label for the distribution (default: local-<date>)")
+}
+
+func runReferenceBuild(cmd *cobra.Command, args []string) error {
+	store, err := history.NewDefaultStore()
+	if err != nil {
+		return err
+	}
+
+	// Stored snapshots are rescored offline
+	service, err := newOfflineService()
+	if err != nil {
+		return err
+	}
+
+	logins, err := store.Logins()
+	if err != nil {
+		return err
+	}
+
+	// Use the latest snapshot of every user so frequently profiled users are not over-represented
+	var scores []float64
+	for _, login := range logins {
+		snapshots, err := store.List(login)
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			continue
+		}
+
+		ranking := service.Rescore(snapshots[len(snapshots)-1].Profile)
+		scores = append(scores, ranking.TotalScore)
+	}
+
+	version := referenceVersion
+	if version == "" {
+		version = "local-" + time.Now().Format("20060102")
+	}
+
+	source := fmt.Sprintf("Built from %d stored profiles in %s", len(scores), store.Dir())
+	dist, err := scoring.BuildDistribution(version, source, service.ScoringModel(), scores)
+	if err != nil {
+		return fmt.Errorf("cannot build a distribution: %w", err)
+	}
+
+	if len(scores) < 100 {
+		fmt.Fprintf(os.Stderr, "Warning: only %d profiles stored; percentiles will be coarse\n", len(scores))
+	}
+
+	if referenceOutput == "" {
+		return output.WriteJSON(os.Stdout, dist)
+	}
+
+	file, err := os.Create(referenceOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", referenceOutput, err)
+	}
+	defer file.Close()
+
+	if err := output.WriteJSON(file, dist); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote reference distribution %s (%d profiles) to %s\n", version, len(scores), referenceOutput)
+	return nil
+}
@@ -1,22 +1,71 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github-profiler/internal/auth"
+	"github-profiler/internal/config"
+	"github-profiler/internal/history"
+	"github-profiler/internal/models"
+	"github-profiler/internal/output"
+	"github-profiler/internal/scoring"
+	"github-profiler/internal/services"
 	"github-profiler/internal/ui"
+	"github-profiler/pkg/profiler"
 )
 
 var (
-	githubToken  string
-	outputFormat string
-	version      = "1.0.0"
-	author       = "github@Tyeflu"
+	githubToken       string
+	appID             int64
+	appInstallationID int64
+	appPrivateKey     string
+	apiURL            string
+	concurrency       int
+	cacheDir          string
+	outputFormat      string
+	watchInterval     time.Duration
+	scoringFile       string
+	referenceFile     string
+	explainRank       bool
+	themeName         string
+	version           = "1.0.0"
+	author            = "github@Tyeflu"
+
+	// userConfig holds the config file with environment overrides applied;
+	// flags have already been filled from it
+	userConfig = &config.Config{}
+
+	// tokenSource describes where githubToken came from; empty when there is no token
+	tokenSource  string
+	discoverOnce sync.Once
 )
 
+// configFlags maps the flags that default to a config setting to its key
+var configFlags = map[string]string{
+	"token":               "token",
+	"app-id":              "app.id",
+	"app-installation-id": "app.installation_id",
+	"app-private-key":     "app.private_key",
+	"api-url":             "api_url",
+	"concurrency":         "concurrency",
+	"cache-dir":           "cache.dir",
+	"cache-ttl":           "cache.ttl",
+	"scoring":             "scoring",
+	"theme":               "theme",
+	"format":              "format",
+}
+
+// minWatchInterval keeps watch mode from hammering the API
+const minWatchInterval = 10 * time.Second
+
 var rootCmd = &cobra.Command{
 	Use:   "github-profiler [username]",
 	Short: "A beautiful CLI tool to analyze GitHub user profiles",
@@ -27,6 +76,9 @@ advanced statistics, and ranking information.
 Use 'github-profiler demo' to see the tool in action with sample data.`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runProfiler,
+	// Errors are reported once by main; usage is only useful for flag mistakes
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 var demoCmd = &cobra.Command{
@@ -34,6 +86,10 @@ var demoCmd = &cobra.Command{
 	Short: "Run a demo with sample data",
 	Long:  `Demonstrates the GitHub Profiler output using mock data.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if outputFormat != "tui" {
+			exitOnError(writeReport("demo-user"))
+			return
+		}
 		runTUI("demo-user")
 	},
 }
@@ -43,32 +99,318 @@ func Execute() error {
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&githubToken, "token", "t", "", "GitHub personal access token (optional for public data)")
-	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "tui", "Output format: tui, json, html")
+	rootCmd.PersistentPreRunE = loadConfig
+	rootCmd.PersistentFlags().StringVarP(&githubToken, "token", "t", "", "GitHub personal access token (optional for public data; default from the environment, config, gh CLI, git credentials or keyring)")
+	rootCmd.PersistentFlags().Int64Var(&appID, "app-id", 0, "Authenticate as this GitHub App instead of with a token")
+	rootCmd.PersistentFlags().Int64Var(&appInstallationID, "app-installation-id", 0, "Installation of the GitHub App to act as")
+	rootCmd.PersistentFlags().StringVar(&appPrivateKey, "app-private-key", "", "Path to the GitHub App's private key (PEM)")
+	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "GitHub API base URL, e.g. https://github.example.com/api/v3/ for GitHub Enterprise Server")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 1, "Number of API requests sent at once while fetching a profile")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Directory for cached GitHub responses (default $XDG_CACHE_HOME/github-profiler)")
+	rootCmd.PersistentFlags().StringVar(&scoringFile, "scoring", "", "Scoring rules file (YAML); the built-in model is used when empty")
+	rootCmd.PersistentFlags().StringVar(&referenceFile, "reference", "", "Reference score distribution (JSON) used for percentiles; when empty, the bundled one built with the scoring rules is used if there is one")
+	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "tui", "Output format: tui, json, html, markdown, svg")
+	rootCmd.Flags().BoolVar(&explainRank, "explain", false, "Include the ranking explanation (rules fired and improvement hints) in JSON output")
+	rootCmd.Flags().DurationVarP(&watchInterval, "watch", "w", 0, "Keep the TUI open and refresh the profile at this interval, e.g. 5m")
+	rootCmd.Flags().StringVar(&themeName, "theme", "", "TUI theme: auto, dark, light, high-contrast, monochrome (default from config, then auto)")
 
 	rootCmd.AddCommand(demoCmd)
-	demoCmd.Flags().StringVarP(&outputFormat, "format", "f", "tui", "Output format: tui, json, html")
+	demoCmd.Flags().StringVarP(&outputFormat, "format", "f", "tui", "Output format: tui, json, html, markdown, svg")
+	demoCmd.Flags().BoolVar(&explainRank, "explain", false, "Include the ranking explanation (rules fired and improvement hints) in JSON output")
+	demoCmd.Flags().DurationVarP(&watchInterval, "watch", "w", 0, "Keep the TUI open and refresh the profile at this interval, e.g. 5m")
+	demoCmd.Flags().StringVar(&themeName, "theme", "", "TUI theme: auto, dark, light, high-contrast, monochrome (default from config, then auto)")
+}
+
+// loadConfig layers the settings before any command runs: flags given on the
+// command line win over GITHUB_PROFILER_* variables (and GITHUB_TOKEN), which
+// win over the config file
+func loadConfig(cmd *cobra.Command, args []string) error {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	fileToken := cfg.Token
+	if err := cfg.ApplyEnv(os.Getenv); err != nil {
+		return err
+	}
+
+	for name, key := range configFlags {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		// Other commands have a --format flag with different values
+		if name == "format" && cmd != rootCmd && cmd != demoCmd {
+			continue
+		}
+
+		value, _ := cfg.Get(key)
+		if value == "" {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid %s from config: %w", key, err)
+		}
+	}
+
+	if cacheDir == "" {
+		if dir, err := config.DefaultCacheDir(); err == nil {
+			cacheDir = dir
+		}
+	}
+
+	resolveToken(cmd, fileToken)
+	userConfig = cfg
+	return nil
+}
 
-	if githubToken == "" {
-		githubToken = os.Getenv("GITHUB_TOKEN")
+// resolveToken records where an explicitly configured token came from
+func resolveToken(cmd *cobra.Command, fileToken string) {
+	tokenEnv := config.EnvVar("token")
+	switch {
+	case appConfigured():
+		tokenSource = fmt.Sprintf("GitHub App %d, installation %d", appID, appInstallationID)
+	case cmd.Flags().Changed("token"):
+		tokenSource = "--token flag"
+	case os.Getenv(tokenEnv) != "":
+		tokenSource = tokenEnv
+	case os.Getenv("GITHUB_TOKEN") != "":
+		tokenSource = "GITHUB_TOKEN"
+	case fileToken != "":
+		tokenSource = "config file"
 	}
 }
 
+// discoverToken looks up a token, when none was configured, where other tools
+// keep it: GH_TOKEN, the gh CLI, git credential helpers and the keyring, so
+// tokens never have to be pasted into a shell. Helpers may run subprocesses or
+// prompt for a keychain, so this only happens once a command talks to GitHub.
+func discoverToken() {
+	discoverOnce.Do(func() {
+		if tokenSource != "" {
+			return
+		}
+		if token, ok := auth.Discover(context.Background(), auth.HostFromAPIURL(apiURL), os.Getenv); ok {
+			githubToken = token.Value
+			tokenSource = token.Source
+		}
+	})
+}
+
 func runProfiler(cmd *cobra.Command, args []string) {
 	username := ""
 	if len(args) > 0 {
 		username = args[0]
 	}
 
-	// For now, always use TUI mode for the best experience
-	// Later we can add back JSON/HTML output modes
+	if outputFormat != "tui" {
+		if username == "" {
+			exitOnError(fmt.Errorf("a username is required for %s output", outputFormat))
+		}
+		exitOnError(writeReport(username))
+		return
+	}
+
 	runTUI(username)
 }
 
+// writeReport fetches a profile and writes it to stdout in the requested format
+func writeReport(username string) error {
+	var profile *models.UserProfile
+	if username == "demo-user" {
+		// The demo profile is built offline, so no token is looked up
+		service, err := newOfflineService()
+		if err != nil {
+			return err
+		}
+		profile, err = service.GetDemoProfile()
+		if err != nil {
+			return err
+		}
+	} else {
+		service, err := newService()
+		if err != nil {
+			return err
+		}
+		profile, err = service.GetUserProfile(username)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Demo data is never persisted so it cannot pollute real trends
+	var snapshots []history.Snapshot
+	if username != "demo-user" {
+		snapshots = saveSnapshot(profile)
+	}
+
+	switch outputFormat {
+	case "json":
+		return output.WriteJSON(os.Stdout, profile)
+	case "html":
+		return output.WriteHTML(os.Stdout, profile, snapshots)
+	case "markdown":
+		return output.WriteMarkdown(os.Stdout, profile)
+	case "svg":
+		return output.WriteSVGCard(os.Stdout, profile)
+	default:
+		return fmt.Errorf("unknown output format %q", outputFormat)
+	}
+}
+
+// serviceOptions builds the GitHub service options from the command line flags
+func serviceOptions() ([]services.Option, error) {
+	opts := []services.Option{
+		services.WithConcurrency(concurrency),
+		services.WithCacheDir(cacheDir),
+	}
+
+	if apiURL != "" {
+		baseURL, err := url.Parse(apiURL)
+		if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+			return nil, fmt.Errorf("invalid --api-url %q", apiURL)
+		}
+		opts = append(opts, services.WithBaseURL(baseURL))
+	}
+
+	if appConfigured() {
+		data, err := readAppPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		key, err := services.ParsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("GitHub App private key: %w", err)
+		}
+		opts = append(opts, services.WithApp(services.App{
+			ID:             appID,
+			InstallationID: appInstallationID,
+			PrivateKey:     key,
+		}))
+	}
+
+	if scoringFile != "" {
+		model, err := scoring.Load(scoringFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, services.WithScoring(model))
+	}
+
+	if referenceFile != "" {
+		dist, err := scoring.LoadDistribution(referenceFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, services.WithReference(dist))
+	}
+
+	if explainRank {
+		opts = append(opts, services.WithExplanation())
+	}
+
+	return opts, nil
+}
+
+// profilerOptions builds the profiler client options from the command line flags
+func profilerOptions() ([]profiler.Option, error) {
+	opts := []profiler.Option{
+		profiler.WithToken(githubToken),
+		profiler.WithBaseURL(apiURL),
+		profiler.WithConcurrency(concurrency),
+		profiler.WithCacheDir(cacheDir),
+		profiler.WithScoringFile(scoringFile),
+		profiler.WithReferenceFile(referenceFile),
+	}
+
+	if appConfigured() {
+		data, err := readAppPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, profiler.WithApp(appID, appInstallationID, data))
+	}
+	return opts, nil
+}
+
+// newService creates a GitHub service configured from the command line flags
+func newService() (*services.GitHubService, error) {
+	discoverToken()
+	return newOfflineService()
+}
+
+// newOfflineService is newService for commands that only rank stored or demo
+// profiles, so no token is looked up
+func newOfflineService() (*services.GitHubService, error) {
+	opts, err := serviceOptions()
+	if err != nil {
+		return nil, err
+	}
+	return services.NewGitHubService(githubToken, opts...), nil
+}
+
+// saveSnapshot records the profile in the history store and returns every
+// snapshot known for the user. Failures are reported but never fatal.
+func saveSnapshot(profile *models.UserProfile) []history.Snapshot {
+	store, err := history.NewDefaultStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: snapshot not saved: %v\n", err)
+		return nil
+	}
+
+	if _, err := store.Save(profile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: snapshot not saved: %v\n", err)
+		return nil
+	}
+
+	snapshots, err := store.List(profile.User.Login)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load snapshots: %v\n", err)
+		return nil
+	}
+	return snapshots
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 func runTUI(username string) {
-	model := ui.NewModel(username, githubToken, outputFormat)
+	if watchInterval > 0 && watchInterval < minWatchInterval {
+		exitOnError(fmt.Errorf("--watch interval must be at least %s", minWatchInterval))
+	}
+
+	// The demo never leaves its sample profile, so it needs no token
+	if username != "demo-user" {
+		discoverToken()
+	}
+
+	// The Ranking view can always expand into the full score explanation
+	opts, err := profilerOptions()
+	exitOnError(err)
+	client, err := profiler.New(append(opts, profiler.WithExplanation())...)
+	exitOnError(err)
+
+	theme, err := ui.ResolveTheme(themeName)
+	exitOnError(err)
+
+	model, err := ui.NewModel(username, outputFormat, client).
+		WithWatch(watchInterval).
+		WithTheme(theme).
+		WithKeyMap(userConfig.Keymap)
+	exitOnError(err)
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(ui.NewApp(model), tea.WithAltScreen(), ui.WithTerminal())
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running program: %v\n", err)
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/scoring"
+)
+
+var scoringCmd = &cobra.Command{
+	Use:   "scoring",
+	Short: "Inspect the ranking scoring model",
+	Long: `Ranking scores are computed from a declarative rules file. Use
+'github-profiler scoring default' to print the built-in rules as a starting
+point, then pass your edited copy with --scoring.`,
+}
+
+var scoringDefaultCmd = &cobra.Command{
+	Use:   "default",
+	Short: "Print the built-in scoring rules",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := os.Stdout.Write(scoring.DefaultRules())
+		return err
+	},
+}
+
+var scoringCheckCmd = &cobra.Command{
+	Use:   "check <file>",
+	Short: "Validate a scoring rules file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		model, err := scoring.Load(args[0])
+		if err != nil {
+			return err
+		}
+		cmd.Printf("%s: valid scoring model %q (max score %.1f, %d tiers)\n",
+			args[0], model.Name, model.MaxScore(), len(model.Tiers))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scoringCmd)
+	scoringCmd.AddCommand(scoringDefaultCmd)
+	scoringCmd.AddCommand(scoringCheckCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/server"
+)
+
+var (
+	serveAddr     string
+	serveCacheTTL time.Duration
+)
+
+// shutdownTimeout is how long in-flight requests get to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve profiles as a JSON API over HTTP",
+	Long: `Runs an HTTP server exposing the profiler as a JSON API:
+
+  GET /api/v1/users/{login}          full profile
+  GET /api/v1/users/{login}/ranking  ranking only
+  GET /api/v1/compare?u=a&u=b        side-by-side comparison
+  GET /api/v1/orgs/{login}           organization profile
+  GET /healthz                       liveness check
+
+Profiles are cached for --cache-ttl and concurrent requests for the same
+login share a single fetch.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().DurationVar(&serveCacheTTL, "cache-ttl", server.DefaultCacheTTL, "How long fetched profiles are served before fetching them again (0 disables the cache)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	service, err := newService()
+	if err != nil {
+		return err
+	}
+
+	handler := server.New(service, server.WithCacheTTL(serveCacheTTL))
+	return listenAndServe(serveAddr, handler, func() {
+		fmt.Fprintf(os.Stderr, "Serving the profile API on %s\n", serveAddr)
+	})
+}
+
+// listenAndServe runs handler on addr until the process is interrupted, then
+// gives in-flight requests a moment to finish. ready is called once the
+// listener is open.
+func listenAndServe(addr string, handler http.Handler, ready func()) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.Serve(listener)
+	}()
+	ready()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
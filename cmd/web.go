@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-profiler/internal/browser"
+	"github-profiler/internal/server"
+	"github-profiler/internal/services"
+	"github-profiler/internal/web"
+)
+
+var (
+	webAddr     string
+	webOpen     bool
+	webCacheTTL time.Duration
+)
+
+var webCmd = &cobra.Command{
+	Use:   "web",
+	Short: "Open a local web dashboard",
+	Long: `Runs a local web dashboard for people who prefer a browser to the terminal.
+It offers user and organization search, the same five sections as the TUI,
+interactive charts and a side-by-side comparison of several users.
+
+All assets are built into the binary, so the dashboard works offline apart
+from the GitHub API calls needed to fetch profiles.`,
+	Args: cobra.NoArgs,
+	RunE: runWeb,
+}
+
+func init() {
+	rootCmd.AddCommand(webCmd)
+	webCmd.Flags().StringVar(&webAddr, "addr", "localhost:8080", "Address to listen on")
+	webCmd.Flags().BoolVar(&webOpen, "open", false, "Open the dashboard in the default browser")
+	webCmd.Flags().DurationVar(&webCacheTTL, "cache-ttl", server.DefaultCacheTTL, "How long fetched profiles are served before fetching them again (0 disables the cache)")
+}
+
+func runWeb(cmd *cobra.Command, args []string) error {
+	discoverToken()
+	opts, err := serviceOptions()
+	if err != nil {
+		return err
+	}
+	// The Ranking section shows how the score was reached
+	opts = append(opts, services.WithExplanation())
+	service := services.NewGitHubService(githubToken, opts...)
+
+	api := server.New(service, server.WithCacheTTL(webCacheTTL))
+	return listenAndServe(webAddr, web.Handler(api), func() {
+		url := dashboardURL(webAddr)
+		fmt.Fprintf(os.Stderr, "Web dashboard running at %s\n", url)
+		if webOpen {
+			if err := browser.Open(url); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not open a browser: %v\n", err)
+			}
+		}
+	})
+}
+
+// dashboardURL turns a listen address into a URL a browser can open
+func dashboardURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/"
+}
@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultHost is the host of github.com accounts
+const DefaultHost = "github.com"
+
+// KeyringService is the Secret Service attribute under which tokens are looked up
+const KeyringService = "github-profiler"
+
+// helperTimeout bounds how long a credential helper or keyring may take to answer
+const helperTimeout = 5 * time.Second
+
+// Token is a credential together with a description of where it was found
+type Token struct {
+	Value  string
+	Source string
+}
+
+// finder looks for a token for host in one place
+type finder func(ctx context.Context, host string, getenv func(string) string) (Token, error)
+
+// finders are tried in order; the first token found wins
+var finders = []finder{
+	fromGHEnv,
+	fromGHHosts,
+	fromGitCredential,
+	fromKeyring,
+}
+
+// Discover looks for a token for host in the places other tools already keep
+// one: GH_TOKEN, the gh CLI's hosts.yml, git credential helpers and the Secret
+// Service keyring. Sources that are missing or fail are skipped.
+func Discover(ctx context.Context, host string, getenv func(string) string) (Token, bool) {
+	for _, find := range finders {
+		token, err := find(ctx, host, getenv)
+		if err == nil && token.Value != "" {
+			return token, true
+		}
+	}
+	return Token{}, false
+}
+
+// HostFromAPIURL returns the host whose credentials apply to an API base URL.
+// An empty URL means github.com.
+func HostFromAPIURL(apiURL string) string {
+	if apiURL == "" {
+		return DefaultHost
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Hostname() == "" {
+		return DefaultHost
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "api.github.com":
+		return DefaultHost
+	case strings.HasPrefix(host, "api.") && strings.HasSuffix(host, ".ghe.com"):
+		// GitHub Enterprise Cloud with data residency serves the API from a subdomain
+		return strings.TrimPrefix(host, "api.")
+	default:
+		// GitHub Enterprise Server serves the API below /api/v3 on the same host
+		return host
+	}
+}
+
+// Mask hides all but the prefix of a token, e.g. gho_****
+func Mask(token string) string {
+	if i := strings.LastIndex(token, "_"); i > 0 && i < 12 {
+		return token[:i+1] + "****"
+	}
+	return "****"
+}
+
+// fromGHEnv reads the variables the gh CLI reads: GH_TOKEN for github.com and
+// GH_ENTERPRISE_TOKEN for other hosts
+func fromGHEnv(ctx context.Context, host string, getenv func(string) string) (Token, error) {
+	names := []string{"GH_TOKEN"}
+	if host != DefaultHost {
+		names = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, name := range names {
+		if value := getenv(name); value != "" {
+			return Token{Value: value, Source: name}, nil
+		}
+	}
+	return Token{}, nil
+}
+
+// fromGHHosts reads the token gh stores in hosts.yml. Recent gh versions keep
+// it in the keyring instead, which fromKeyring covers.
+func fromGHHosts(ctx context.Context, host string, getenv func(string) string) (Token, error) {
+	path, err := ghHostsPath(getenv)
+	if err != nil {
+		return Token{}, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Token{}, nil
+	}
+	if err != nil {
+		return Token{}, err
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return Token{}, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	entry, ok := hosts[host]
+	if !ok || entry.OAuthToken == "" {
+		return Token{}, nil
+	}
+	return Token{Value: entry.OAuthToken, Source: "gh CLI (" + path + ")"}, nil
+}
+
+// ghHostsPath locates hosts.yml the way gh does
+func ghHostsPath(getenv func(string) string) (string, error) {
+	if dir := getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml"), nil
+	}
+	if dir := getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml"), nil
+	}
+	if runtime.GOOS == "windows" {
+		if dir := getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI", "hosts.yml"), nil
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "gh", "hosts.yml"), nil
+}
+
+// fromGitCredential asks the configured git credential helpers for the host.
+// Prompts are disabled so a missing credential never blocks.
+func fromGitCredential(ctx context.Context, host string, getenv func(string) string) (Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, helperTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=" + host + "\n\n")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never", "GIT_ASKPASS=")
+	out, err := cmd.Output()
+	if err != nil {
+		return Token{}, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if password, ok := strings.CutPrefix(scanner.Text(), "password="); ok {
+			return Token{Value: password, Source: "git credential helper"}, nil
+		}
+	}
+	return Token{}, nil
+}
+
+// fromKeyring looks the host up in the Secret Service keyring with secret-tool,
+// first under this tool's own entry and then under the entry gh creates
+func fromKeyring(ctx context.Context, host string, getenv func(string) string) (Token, error) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "freebsd" && runtime.GOOS != "openbsd" {
+		return Token{}, nil
+	}
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return Token{}, nil
+	}
+
+	lookups := [][]string{
+		{"service", KeyringService, "host", host},
+		{"service", "gh:" + host},
+	}
+	for _, attributes := range lookups {
+		value, err := secretToolLookup(ctx, attributes)
+		if err == nil && value != "" {
+			return Token{Value: value, Source: "Secret Service keyring (" + attributes[1] + ")"}, nil
+		}
+	}
+	return Token{}, nil
+}
+
+func secretToolLookup(ctx context.Context, attributes []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, helperTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "secret-tool", append([]string{"lookup"}, attributes...)...).Output()
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSpace(string(out))
+	// gh encodes the token it stores in the keyring
+	if encoded, ok := strings.CutPrefix(value, "go-keyring-base64:"); ok {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		return string(decoded), err
+	}
+	return value, nil
+}
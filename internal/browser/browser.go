@@ -0,0 +1,76 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// ErrNoBrowser is returned when no graphical browser can be launched, for
+// example over SSH or on a headless machine
+var ErrNoBrowser = errors.New("no browser available")
+
+// Open launches url in the system browser without waiting for it to exit
+func Open(url string) error {
+	name, args, err := command(url)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to launch browser: %w", err)
+	}
+
+	// Reap the launcher in the background so it never becomes a zombie
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// Copy asks the terminal to place text on its clipboard by writing an OSC52
+// escape sequence to w, which should be the output the terminal is drawn on.
+// This works over SSH as long as the terminal emulator supports OSC52; there is
+// no reply, so success cannot be confirmed.
+func Copy(w io.Writer, text string) error {
+	seq := osc52.New(text)
+	switch term := os.Getenv("TERM"); {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(term, "screen"):
+		seq = seq.Screen()
+	}
+
+	if _, err := seq.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
+
+// command returns the platform specific launcher for url
+func command(url string) (string, []string, error) {
+	if browser := os.Getenv("BROWSER"); browser != "" {
+		return browser, []string{url}, nil
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return "open", []string{url}, nil
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}, nil
+	default:
+		// xdg-open may fall back to a text browser, which would take over the TUI
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return "", nil, ErrNoBrowser
+		}
+		if _, err := exec.LookPath("xdg-open"); err != nil {
+			return "", nil, ErrNoBrowser
+		}
+		return "xdg-open", []string{url}, nil
+	}
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// entry is a fetched value and when it stops being served
+type entry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// Cache keeps fetched values for a fixed time so repeated requests for the
+// same login are answered without calling GitHub
+type Cache[T any] struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry[T]
+}
+
+// New creates a cache serving values for ttl. A zero ttl stores nothing.
+func New[T any](ttl time.Duration) *Cache[T] {
+	return &Cache[T]{
+		ttl:     ttl,
+		entries: make(map[string]entry[T]),
+	}
+}
+
+// TTL returns how long values are served
+func (c *Cache[T]) TTL() time.Duration {
+	return c.ttl
+}
+
+// Get returns the cached value for key if it has not expired
+func (c *Cache[T]) Get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		delete(c.entries, key)
+		var zero T
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Put stores a value, dropping expired entries so the cache does not grow without bound
+func (c *Cache[T]) Put(key string, value T) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry[T]{value: value, expires: now.Add(c.ttl)}
+}
+
+// Fetch returns the value for key from c, or fetches it once through calls
+// and stores the result. ctx only bounds the wait for the fetch.
+func Fetch[T any](ctx context.Context, c *Cache[T], calls *Group[T], key string, fetch func() (T, error)) (T, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	return calls.Do(ctx, key, func() (T, error) {
+		value, err := fetch()
+		if err != nil {
+			return value, err
+		}
+		c.Put(key, value)
+		return value, nil
+	})
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// call is a fetch in progress that other requests can wait on
+type call[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Group coalesces concurrent fetches of the same key into a single fetch. The
+// zero value is ready to use.
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+// Do runs fetch for key unless a fetch for key is already running, and waits
+// for its result. The fetch runs on its own, so every caller, including the one
+// that started it, stops waiting when its ctx is done without cancelling it
+// for the others.
+func (g *Group[T]) Do(ctx context.Context, key string, fetch func() (T, error)) (T, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call[T])
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = &call[T]{done: make(chan struct{})}
+		g.calls[key] = c
+		go g.run(key, c, fetch)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// run performs the fetch of c and releases key, even if fetch panics, so later
+// requests are not stuck
+func (g *Group[T]) run(key string, c *call[T], fetch func() (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = fmt.Errorf("fetch of %s panicked: %v", key, r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+
+	c.value, c.err = fetch()
+}
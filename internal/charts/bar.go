@@ -0,0 +1,33 @@
+package charts
+
+import (
+	"math"
+	"strings"
+)
+
+// barEighths are the partial blocks used for the fractional end of a bar, narrowest first
+var barEighths = []rune("▏▎▍▌▋▊▉")
+
+// Bar renders value as a horizontal bar scaled so max fills width columns.
+// The final column uses eighth blocks so small differences stay visible, and
+// the rest of the bar is padded with spaces to exactly width columns.
+func Bar(value, max float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	eighths := 0
+	if max > 0 && value > 0 {
+		eighths = int(math.Round(math.Min(value/max, 1) * float64(width*8)))
+	}
+
+	full, part := eighths/8, eighths%8
+	var b strings.Builder
+	b.WriteString(strings.Repeat("█", full))
+	if part > 0 {
+		b.WriteRune(barEighths[part-1])
+		full++
+	}
+	b.WriteString(strings.Repeat(" ", width-full))
+	return b.String()
+}
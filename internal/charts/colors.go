@@ -0,0 +1,71 @@
+package charts
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// languageColors are the colours GitHub linguist assigns to common languages
+var languageColors = map[string]string{
+	"assembly":         "#6e4c13",
+	"c":                "#555555",
+	"c#":               "#178600",
+	"c++":              "#f34b7d",
+	"clojure":          "#db5855",
+	"coffeescript":     "#244776",
+	"css":              "#663399",
+	"dart":             "#00b4ab",
+	"dockerfile":       "#384d54",
+	"elixir":           "#6e4a7e",
+	"elm":              "#60b5cc",
+	"erlang":           "#b83998",
+	"f#":               "#b845fc",
+	"fortran":          "#4d41b1",
+	"go":               "#00add8",
+	"groovy":           "#4298b8",
+	"haskell":          "#5e5086",
+	"hcl":              "#844fba",
+	"html":             "#e34c26",
+	"java":             "#b07219",
+	"javascript":       "#f1e05a",
+	"julia":            "#a270ba",
+	"jupyter notebook": "#da5b0b",
+	"kotlin":           "#a97bff",
+	"lua":              "#000080",
+	"makefile":         "#427819",
+	"nix":              "#7e7eff",
+	"objective-c":      "#438eff",
+	"ocaml":            "#ef7a08",
+	"perl":             "#0298c3",
+	"php":              "#4f5d95",
+	"powershell":       "#012456",
+	"python":           "#3572a5",
+	"r":                "#198ce7",
+	"ruby":             "#701516",
+	"rust":             "#dea584",
+	"scala":            "#c22d40",
+	"scss":             "#c6538c",
+	"shell":            "#89e051",
+	"svelte":           "#ff3e00",
+	"swift":            "#f05138",
+	"tex":              "#3d6117",
+	"typescript":       "#3178c6",
+	"vim script":       "#199f4b",
+	"vue":              "#41b883",
+	"zig":              "#ec915c",
+}
+
+// fallbackColors are used for languages linguist colours are not known for
+var fallbackColors = []string{"#5fd7d7", "#d75f87", "#87d75f", "#d7af5f", "#af87d7", "#5f87d7"}
+
+// LanguageColor returns the hex colour GitHub uses for a language. Unknown
+// languages get a stable colour derived from their name.
+func LanguageColor(name string) string {
+	if color, ok := languageColors[strings.ToLower(name)]; ok {
+		return color
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(name)))
+	return fallbackColors[h.Sum32()%uint32(len(fallbackColors))]
+}
@@ -0,0 +1,81 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// columnEighths are the partial blocks used for the top of a column, shortest first
+var columnEighths = []rune("▁▂▃▄▅▆▇")
+
+// Columns renders a vertical column chart with one column per value, height
+// rows tall, with each value printed above its column and its label below.
+// Columns are as wide as the widest label or value.
+func Columns(values []float64, labels []string, height int) string {
+	if len(values) == 0 || height <= 0 {
+		return ""
+	}
+
+	counts := make([]string, len(values))
+	colWidth := 1
+	peak := 0.0
+	for i, v := range values {
+		counts[i] = fmt.Sprintf("%g", v)
+		colWidth = max(colWidth, lipgloss.Width(counts[i]))
+		if i < len(labels) {
+			colWidth = max(colWidth, lipgloss.Width(labels[i]))
+		}
+		peak = max(peak, v)
+	}
+
+	// Heights in eighths of a row
+	eighths := make([]int, len(values))
+	for i, v := range values {
+		if peak > 0 && v > 0 {
+			eighths[i] = max(int(math.Round(v/peak*float64(height*8))), 1)
+		}
+	}
+
+	var rows []string
+	rows = append(rows, joinCells(counts, colWidth))
+	for row := height - 1; row >= 0; row-- {
+		cells := make([]string, len(values))
+		for i, e := range eighths {
+			cells[i] = columnCell(e-row*8, colWidth)
+		}
+		rows = append(rows, joinCells(cells, colWidth))
+	}
+
+	padded := make([]string, len(values))
+	copy(padded, labels)
+	rows = append(rows, joinCells(padded, colWidth))
+
+	return strings.Join(rows, "\n")
+}
+
+// columnCell draws the part of a column that falls in one row, given how many
+// eighths of the column reach into that row
+func columnCell(eighths, width int) string {
+	switch {
+	case eighths <= 0:
+		return ""
+	case eighths >= 8:
+		return strings.Repeat("█", width)
+	default:
+		return strings.Repeat(string(columnEighths[eighths-1]), width)
+	}
+}
+
+// joinCells centres each cell in a column of width and separates columns by a space
+func joinCells(cells []string, width int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		gap := width - lipgloss.Width(cell)
+		left := gap / 2
+		padded[i] = strings.Repeat(" ", left) + cell + strings.Repeat(" ", gap-left)
+	}
+	return strings.TrimRight(strings.Join(padded, " "), " ")
+}
@@ -0,0 +1,63 @@
+package charts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sparkTicks are the block characters used for text sparklines, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := bounds(values)
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > min {
+			idx = int((v - min) / (max - min) * float64(len(sparkTicks)-1))
+		}
+		b.WriteRune(sparkTicks[idx])
+	}
+	return b.String()
+}
+
+// SparklinePoints returns SVG polyline points for values scaled to width x height
+func SparklinePoints(values []float64, width, height float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) == 1 {
+		values = []float64{values[0], values[0]}
+	}
+
+	min, max := bounds(values)
+	step := width / float64(len(values)-1)
+
+	points := make([]string, len(values))
+	for i, v := range values {
+		y := height / 2
+		if max > min {
+			y = height - (v-min)/(max-min)*height
+		}
+		points[i] = fmt.Sprintf("%.1f,%.1f", float64(i)*step, y)
+	}
+	return strings.Join(points, " ")
+}
+
+func bounds(values []float64) (min, max float64) {
+	min, max = values[0], values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max
+}
@@ -0,0 +1,57 @@
+package charts
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// monochromeFills tell adjacent segments apart when colour is unavailable
+var monochromeFills = []string{"█", "▓", "▒", "░"}
+
+// Segment is one part of a stacked bar
+type Segment struct {
+	Value float64
+	Color lipgloss.TerminalColor
+}
+
+// StackedBar renders segments side by side in a single bar of width columns,
+// each taking a share of the width proportional to its value. Every non-zero
+// segment gets at least one column while there is room. When monochrome is
+// set, segments are told apart by shading instead of colour.
+func StackedBar(segments []Segment, width int, monochrome bool) string {
+	total := 0.0
+	for _, segment := range segments {
+		total += max(segment.Value, 0)
+	}
+	if width <= 0 || total == 0 {
+		return strings.Repeat(" ", max(width, 0))
+	}
+
+	// Round the running total rather than each segment so the widths add up
+	var b strings.Builder
+	used, sum := 0, 0.0
+	for i, segment := range segments {
+		if segment.Value <= 0 {
+			continue
+		}
+		sum += segment.Value
+		end := int(math.Round(sum / total * float64(width)))
+		end = min(max(end, used+1), width)
+		if end <= used {
+			break
+		}
+
+		fill := "█"
+		style := lipgloss.NewStyle().Foreground(segment.Color)
+		if monochrome {
+			fill = monochromeFills[i%len(monochromeFills)]
+			style = lipgloss.NewStyle()
+		}
+		b.WriteString(style.Render(strings.Repeat(fill, end-used)))
+		used = end
+	}
+	b.WriteString(strings.Repeat(" ", width-used))
+	return b.String()
+}
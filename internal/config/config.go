@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the user preferences read from the config file and the environment
+type Config struct {
+	// Token is the GitHub token used for API requests
+	Token string `yaml:"token,omitempty"`
+
+	// App authenticates as a GitHub App installation instead of with a token
+	App App `yaml:"app,omitempty"`
+
+	// APIURL points the client at a GitHub Enterprise Server, e.g. https://github.example.com/api/v3/
+	APIURL string `yaml:"api_url,omitempty"`
+
+	// Provider names the code host; only github is supported
+	Provider string `yaml:"provider,omitempty"`
+
+	// Format is the default output format: tui, json, html, markdown or svg
+	Format string `yaml:"format,omitempty"`
+
+	// Theme names the TUI theme: auto, dark, light, high-contrast or monochrome
+	Theme string `yaml:"theme,omitempty"`
+
+	// Keymap overrides TUI key bindings by action name, e.g. "next_view: [l, tab]"
+	Keymap map[string][]string `yaml:"keymap,omitempty"`
+
+	// Concurrency is the number of API requests sent at once while fetching a profile
+	Concurrency int `yaml:"concurrency,omitempty"`
+
+	// Cache configures response caching
+	Cache Cache `yaml:"cache,omitempty"`
+
+	// Scoring is the scoring rules file used instead of the built-in model
+	Scoring string `yaml:"scoring,omitempty"`
+
+	// Exporter lists the accounts the metrics exporter profiles
+	Exporter Exporter `yaml:"exporter,omitempty"`
+}
+
+// App identifies a GitHub App installation
+type App struct {
+	ID             int64 `yaml:"id,omitempty"`
+	InstallationID int64 `yaml:"installation_id,omitempty"`
+
+	// PrivateKey is the path to the App's PEM private key, or the key itself
+	PrivateKey string `yaml:"private_key,omitempty"`
+}
+
+// Configured reports whether any App setting is present
+func (a App) Configured() bool {
+	return a.ID != 0 || a.InstallationID != 0 || a.PrivateKey != ""
+}
+
+// Cache configures where and for how long responses are kept
+type Cache struct {
+	// Dir holds GitHub responses between runs so they can be revalidated cheaply
+	Dir string `yaml:"dir,omitempty"`
+
+	// TTL is how long the servers reuse a fetched profile, e.g. "10m"
+	TTL time.Duration `yaml:"ttl,omitempty"`
+}
+
+// Exporter configures the Prometheus metrics exporter
+type Exporter struct {
+	Users []string `yaml:"users,omitempty"`
+	Orgs  []string `yaml:"orgs,omitempty"`
+
+	// Interval is the time between refreshes, e.g. "15m"
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// DefaultPath returns the config file location following the XDG base directory spec
+func DefaultPath() (string, error) {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "github-profiler", "config.yaml"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "github-profiler", "config.yaml"), nil
+}
+
+// DefaultCacheDir returns the response cache location following the XDG base directory spec
+func DefaultCacheDir() (string, error) {
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+		return filepath.Join(cacheHome, "github-profiler"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "github-profiler"), nil
+}
+
+// Load reads the config file at path. A missing file yields an empty config.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// LoadDefault reads the config file from the default location
+func LoadDefault() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Save writes cfg to path. The file may hold a token, so only the owner can read it.
+func Save(path string, cfg *Config) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	data := buf.Bytes()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
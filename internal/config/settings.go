@@ -0,0 +1,270 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-profiler/internal/ui"
+)
+
+// envPrefix starts the name of every environment variable overriding a setting
+const envPrefix = "GITHUB_PROFILER_"
+
+// Providers lists the supported code hosts
+var Providers = []string{"github"}
+
+// Formats lists the output formats of the root command
+var Formats = []string{"tui", "json", "html", "markdown", "svg"}
+
+// setting is a scalar config key that can be read, written and overridden from the environment
+type setting struct {
+	key string
+	get func(*Config) string
+	set func(*Config, string) error
+}
+
+// env returns the environment variable overriding the setting, e.g. GITHUB_PROFILER_CACHE_TTL
+func (s setting) env() string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(s.key, ".", "_"))
+}
+
+var settings = []setting{
+	{
+		key: "token",
+		get: func(c *Config) string { return c.Token },
+		set: func(c *Config, v string) error { c.Token = v; return nil },
+	},
+	{
+		key: "app.id",
+		get: func(c *Config) string { return formatInt64(c.App.ID) },
+		set: func(c *Config, v string) error {
+			id, err := parseInt64(v)
+			if err != nil || id < 0 {
+				return fmt.Errorf("app.id must be a GitHub App ID, got %q", v)
+			}
+			c.App.ID = id
+			return nil
+		},
+	},
+	{
+		key: "app.installation_id",
+		get: func(c *Config) string { return formatInt64(c.App.InstallationID) },
+		set: func(c *Config, v string) error {
+			id, err := parseInt64(v)
+			if err != nil || id < 0 {
+				return fmt.Errorf("app.installation_id must be an installation ID, got %q", v)
+			}
+			c.App.InstallationID = id
+			return nil
+		},
+	},
+	{
+		key: "app.private_key",
+		get: func(c *Config) string { return c.App.PrivateKey },
+		set: func(c *Config, v string) error { c.App.PrivateKey = v; return nil },
+	},
+	{
+		key: "api_url",
+		get: func(c *Config) string { return c.APIURL },
+		set: func(c *Config, v string) error {
+			if v != "" {
+				u, err := url.Parse(v)
+				if err != nil || u.Scheme == "" || u.Host == "" {
+					return fmt.Errorf("api_url must be an absolute URL, got %q", v)
+				}
+			}
+			c.APIURL = v
+			return nil
+		},
+	},
+	{
+		key: "provider",
+		get: func(c *Config) string { return c.Provider },
+		set: func(c *Config, v string) error {
+			if v != "" && !slices.Contains(Providers, v) {
+				return fmt.Errorf("unsupported provider %q (supported: %s)", v, strings.Join(Providers, ", "))
+			}
+			c.Provider = v
+			return nil
+		},
+	},
+	{
+		key: "format",
+		get: func(c *Config) string { return c.Format },
+		set: func(c *Config, v string) error {
+			if v != "" && !slices.Contains(Formats, v) {
+				return fmt.Errorf("unknown format %q (choose from %s)", v, strings.Join(Formats, ", "))
+			}
+			c.Format = v
+			return nil
+		},
+	},
+	{
+		key: "theme",
+		get: func(c *Config) string { return c.Theme },
+		set: func(c *Config, v string) error {
+			if v != "" && !slices.Contains(ui.ThemeNames(), v) {
+				return fmt.Errorf("unknown theme %q (valid themes: %s)", v, strings.Join(ui.ThemeNames(), ", "))
+			}
+			c.Theme = v
+			return nil
+		},
+	},
+	{
+		key: "concurrency",
+		get: func(c *Config) string { return formatInt(c.Concurrency) },
+		set: func(c *Config, v string) error {
+			n, err := parseInt(v)
+			if err != nil || n < 0 {
+				return fmt.Errorf("concurrency must be a positive number, got %q", v)
+			}
+			c.Concurrency = n
+			return nil
+		},
+	},
+	{
+		key: "cache.dir",
+		get: func(c *Config) string { return c.Cache.Dir },
+		set: func(c *Config, v string) error { c.Cache.Dir = v; return nil },
+	},
+	{
+		key: "cache.ttl",
+		get: func(c *Config) string { return formatDuration(c.Cache.TTL) },
+		set: func(c *Config, v string) error {
+			d, err := parseDuration(v)
+			if err != nil || d < 0 {
+				return fmt.Errorf("cache.ttl must be a duration such as 10m, got %q", v)
+			}
+			c.Cache.TTL = d
+			return nil
+		},
+	},
+	{
+		key: "scoring",
+		get: func(c *Config) string { return c.Scoring },
+		set: func(c *Config, v string) error { c.Scoring = v; return nil },
+	},
+}
+
+// Keys lists the settings that Get and Set accept
+func Keys() []string {
+	keys := make([]string, len(settings))
+	for i, s := range settings {
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// EnvVar names the environment variable overriding key
+func EnvVar(key string) string {
+	if s, ok := lookup(key); ok {
+		return s.env()
+	}
+	return ""
+}
+
+// Get returns the value of a setting, or an empty string when it is unset
+func (c *Config) Get(key string) (string, error) {
+	s, ok := lookup(key)
+	if !ok {
+		return "", unknownKey(key)
+	}
+	return s.get(c), nil
+}
+
+// Set validates and stores the value of a setting. An empty value unsets it.
+func (c *Config) Set(key, value string) error {
+	s, ok := lookup(key)
+	if !ok {
+		return unknownKey(key)
+	}
+	return s.set(c, value)
+}
+
+// Validate checks every setting read from the config file
+func (c *Config) Validate() error {
+	for _, s := range settings {
+		if err := s.set(c, s.get(c)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyEnv overrides settings with the GITHUB_PROFILER_* variables found by
+// getenv. GITHUB_TOKEN is also accepted for the token.
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	if token := getenv("GITHUB_TOKEN"); token != "" {
+		c.Token = token
+	}
+
+	for _, s := range settings {
+		value := getenv(s.env())
+		if value == "" {
+			continue
+		}
+		if err := s.set(c, value); err != nil {
+			return fmt.Errorf("%s: %w", s.env(), err)
+		}
+	}
+	return nil
+}
+
+func lookup(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown config key %q (known keys: %s)", key, strings.Join(Keys(), ", "))
+}
+
+func formatInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func parseInt(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(v)
+}
+
+func formatInt64(n int64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func parseInt64(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+func parseDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(v)
+}
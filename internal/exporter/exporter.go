@@ -0,0 +1,246 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github-profiler/internal/models"
+	"github-profiler/internal/services"
+)
+
+// metricPrefix namespaces every exported metric
+const metricPrefix = "github_profiler_"
+
+// Source fetches the profiles the exporter reports on
+type Source interface {
+	GetUserProfileContext(ctx context.Context, login string) (*models.UserProfile, error)
+	GetOrgProfileContext(ctx context.Context, login string) (*models.OrgProfile, error)
+	APIStats() services.APIStats
+}
+
+// Target is an account the exporter profiles
+type Target struct {
+	Login string
+	Org   bool
+}
+
+// kind is the value of the kind label for the target
+func (t Target) kind() string {
+	if t.Org {
+		return "org"
+	}
+	return "user"
+}
+
+// result is the outcome of the latest refreshes of a target
+type result struct {
+	user *models.UserProfile
+	org  *models.OrgProfile
+
+	// lastSuccess is zero until the first successful refresh; failed refreshes
+	// keep the previous profile so gauges do not drop out on transient errors
+	lastSuccess time.Time
+	lastError   error
+	duration    time.Duration
+	failures    int
+}
+
+// Exporter profiles a fixed set of accounts on an interval and serves the
+// results as Prometheus metrics
+type Exporter struct {
+	source   Source
+	targets  []Target
+	interval time.Duration
+
+	mu      sync.Mutex
+	results map[Target]*result
+}
+
+// New creates an exporter for targets, refreshed every interval once Run is called.
+// Logins are case-insensitive, so targets repeating one are reported once.
+func New(source Source, targets []Target, interval time.Duration) *Exporter {
+	results := make(map[Target]*result, len(targets))
+	seen := make(map[Target]bool, len(targets))
+	var unique []Target
+	for _, target := range targets {
+		key := Target{Login: strings.ToLower(target.Login), Org: target.Org}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		results[target] = &result{}
+		unique = append(unique, target)
+	}
+	return &Exporter{
+		source:   source,
+		targets:  unique,
+		interval: interval,
+		results:  results,
+	}
+}
+
+// Run refreshes every target straight away and then on every interval until ctx is done
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh profiles the targets one at a time to stay gentle on the rate limit
+func (e *Exporter) refresh(ctx context.Context) {
+	for _, target := range e.targets {
+		if ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+		var user *models.UserProfile
+		var org *models.OrgProfile
+		var err error
+		if target.Org {
+			org, err = e.source.GetOrgProfileContext(ctx, target.Login)
+		} else {
+			user, err = e.source.GetUserProfileContext(ctx, target.Login)
+		}
+		// A fetch cut short by shutdown says nothing about the target
+		if ctx.Err() != nil {
+			return
+		}
+
+		e.mu.Lock()
+		r := e.results[target]
+		r.duration = time.Since(start)
+		r.lastError = err
+		if err != nil {
+			r.failures++
+		} else {
+			r.user, r.org = user, org
+			r.lastSuccess = time.Now()
+		}
+		e.mu.Unlock()
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.write(w)
+}
+
+// write renders every metric family
+func (e *Exporter) write(w io.Writer) error {
+	for _, f := range e.collect() {
+		if err := f.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collect builds the metric families from the latest results
+func (e *Exporter) collect() []*family {
+	stars := &family{name: metricPrefix + "stars", help: "Stars across the account's own repositories.", kind: "gauge"}
+	forks := &family{name: metricPrefix + "forks", help: "Forks of the account's own repositories.", kind: "gauge"}
+	followers := &family{name: metricPrefix + "followers", help: "Followers of the account.", kind: "gauge"}
+	publicRepos := &family{name: metricPrefix + "public_repos", help: "Public repositories reported by GitHub.", kind: "gauge"}
+	repos := &family{name: metricPrefix + "repos", help: "Repositories by type (public, private, forks).", kind: "gauge"}
+	languageBytes := &family{name: metricPrefix + "language_bytes", help: "Bytes of code per language across the account's repositories.", kind: "gauge"}
+	members := &family{name: metricPrefix + "org_public_members", help: "Public members of the organization.", kind: "gauge"}
+	score := &family{name: metricPrefix + "score", help: "Ranking score by component (total, social, code, activity, innovation).", kind: "gauge"}
+	percentile := &family{name: metricPrefix + "score_percentile", help: "Percentile of the total score in the reference distribution.", kind: "gauge"}
+
+	up := &family{name: metricPrefix + "refresh_success", help: "Whether the latest refresh of the account succeeded.", kind: "gauge"}
+	lastSuccess := &family{name: metricPrefix + "last_refresh_timestamp_seconds", help: "Unix time of the latest successful refresh.", kind: "gauge"}
+	duration := &family{name: metricPrefix + "refresh_duration_seconds", help: "Time taken by the latest refresh.", kind: "gauge"}
+	failures := &family{name: metricPrefix + "refresh_failures_total", help: "Failed refreshes since the exporter started.", kind: "counter"}
+
+	e.mu.Lock()
+	for _, target := range e.targets {
+		r := e.results[target]
+		login, kind := target.Login, target.kind()
+
+		success := 0.0
+		if r.lastError == nil && !r.lastSuccess.IsZero() {
+			success = 1
+		}
+		up.add(success, "login", login, "kind", kind)
+		failures.add(float64(r.failures), "login", login, "kind", kind)
+		if r.lastSuccess.IsZero() {
+			continue
+		}
+		lastSuccess.add(float64(r.lastSuccess.Unix()), "login", login, "kind", kind)
+		duration.add(r.duration.Seconds(), "login", login, "kind", kind)
+
+		var stats models.ProfileStats
+		var languages models.LanguageStats
+		switch {
+		case r.user != nil:
+			stats, languages = r.user.Stats, r.user.Languages
+			followers.add(float64(r.user.User.Followers), "login", login, "kind", kind)
+			publicRepos.add(float64(r.user.User.PublicRepos), "login", login, "kind", kind)
+
+			ranking := r.user.Ranking
+			for component, value := range map[string]float64{
+				"total":      ranking.TotalScore,
+				"social":     ranking.SocialScore,
+				"code":       ranking.CodeScore,
+				"activity":   ranking.ActivityScore,
+				"innovation": ranking.InnovationScore,
+			} {
+				score.add(value, "login", login, "kind", kind, "component", component)
+			}
+			if ranking.Percentile != nil {
+				percentile.add(*ranking.Percentile, "login", login, "kind", kind)
+			}
+		case r.org != nil:
+			stats, languages = r.org.Stats, r.org.Languages
+			followers.add(float64(r.org.Organization.Followers), "login", login, "kind", kind)
+			publicRepos.add(float64(r.org.Organization.PublicRepos), "login", login, "kind", kind)
+			members.add(float64(len(r.org.Members)), "login", login)
+		}
+
+		stars.add(float64(stats.TotalStars), "login", login, "kind", kind)
+		forks.add(float64(stats.TotalForks), "login", login, "kind", kind)
+		for repoType, count := range stats.RepoTypes {
+			repos.add(float64(count), "login", login, "kind", kind, "type", repoType)
+		}
+		for name, lang := range languages.Languages {
+			languageBytes.add(float64(lang.Bytes), "login", login, "kind", kind, "language", name)
+		}
+	}
+	e.mu.Unlock()
+
+	api := e.source.APIStats()
+	requests := &family{name: metricPrefix + "api_requests_total", help: "Requests sent to the GitHub API by status code.", kind: "counter"}
+	for code, n := range api.Requests {
+		requests.add(float64(n), "code", code)
+	}
+
+	rateLimit := &family{name: metricPrefix + "api_rate_limit", help: "Requests allowed per rate limit window.", kind: "gauge"}
+	rateRemaining := &family{name: metricPrefix + "api_rate_limit_remaining", help: "Requests left in the current rate limit window.", kind: "gauge"}
+	rateReset := &family{name: metricPrefix + "api_rate_limit_reset_timestamp_seconds", help: "Unix time at which the rate limit window resets.", kind: "gauge"}
+	if api.RateKnown {
+		rateLimit.add(float64(api.RateLimit))
+		rateRemaining.add(float64(api.RateRemaining))
+		rateReset.add(float64(api.RateReset.Unix()))
+	}
+
+	return []*family{
+		stars, forks, followers, publicRepos, repos, languageBytes, members,
+		score, percentile,
+		up, lastSuccess, duration, failures,
+		requests, rateLimit, rateRemaining, rateReset,
+	}
+}
@@ -0,0 +1,69 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// family is one metric in the Prometheus text exposition format
+type family struct {
+	name    string
+	help    string
+	kind    string // gauge or counter
+	samples []sample
+}
+
+// sample is a single labelled value of a family
+type sample struct {
+	labels []label
+	value  float64
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+// add appends a sample with labels given as alternating names and values
+func (f *family) add(value float64, labels ...string) {
+	s := sample{value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		s.labels = append(s.labels, label{labels[i], labels[i+1]})
+	}
+	f.samples = append(f.samples, s)
+}
+
+// write renders the family. Samples are sorted by their labels so scrapes
+// are stable, and families without samples are left out entirely.
+func (f *family) write(w io.Writer) error {
+	if len(f.samples) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(f.samples))
+	for i, s := range f.samples {
+		lines[i] = f.name + formatLabels(s.labels) + " " + strconv.FormatFloat(s.value, 'f', -1, 64)
+	}
+	sort.Strings(lines)
+
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s\n", f.name, f.help, f.name, f.kind, strings.Join(lines, "\n"))
+	return err
+}
+
+// labelEscaper escapes label values as the exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = fmt.Sprintf(`%s="%s"`, l.name, labelEscaper.Replace(l.value))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
@@ -0,0 +1,224 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-profiler/internal/models"
+)
+
+// IntDelta describes how an integer metric changed between two snapshots
+type IntDelta struct {
+	From   int `json:"from"`
+	To     int `json:"to"`
+	Change int `json:"change"`
+}
+
+// FloatDelta describes how a floating point metric changed between two snapshots
+type FloatDelta struct {
+	From   float64 `json:"from"`
+	To     float64 `json:"to"`
+	Change float64 `json:"change"`
+}
+
+// LanguageShift describes how the share of a language changed
+type LanguageShift struct {
+	Name       string     `json:"name"`
+	Percentage FloatDelta `json:"percentage"`
+}
+
+// RankingDelta describes how the ranking components changed
+type RankingDelta struct {
+	FromBadge       string     `json:"from_badge"`
+	ToBadge         string     `json:"to_badge"`
+	TotalScore      FloatDelta `json:"total_score"`
+	SocialScore     FloatDelta `json:"social_score"`
+	CodeScore       FloatDelta `json:"code_score"`
+	ActivityScore   FloatDelta `json:"activity_score"`
+	InnovationScore FloatDelta `json:"innovation_score"`
+}
+
+// Diff summarises the changes between two snapshots of the same user
+type Diff struct {
+	Login         string          `json:"login"`
+	From          time.Time       `json:"from"`
+	To            time.Time       `json:"to"`
+	Snapshots     int             `json:"snapshots"`
+	Stars         IntDelta        `json:"stars"`
+	Forks         IntDelta        `json:"forks"`
+	Followers     IntDelta        `json:"followers"`
+	PublicRepos   IntDelta        `json:"public_repos"`
+	NewRepos      []string        `json:"new_repos"`
+	ArchivedRepos []string        `json:"archived_repos"`
+	LanguageShift []LanguageShift `json:"language_shift"`
+	Ranking       RankingDelta    `json:"ranking"`
+	Trends        []Series        `json:"trends"`
+}
+
+// Series is a named metric sampled at every snapshot
+type Series struct {
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"`
+}
+
+// Latest returns the most recent value of the series
+func (s Series) Latest() float64 {
+	if len(s.Values) == 0 {
+		return 0
+	}
+	return s.Values[len(s.Values)-1]
+}
+
+// Change returns the difference between the last and first value of the series
+func (s Series) Change() float64 {
+	if len(s.Values) == 0 {
+		return 0
+	}
+	return s.Values[len(s.Values)-1] - s.Values[0]
+}
+
+// Compare computes the changes across the given snapshots, oldest first
+func Compare(snapshots []Snapshot) (Diff, error) {
+	if len(snapshots) < 2 {
+		return Diff{}, fmt.Errorf("at least two snapshots are needed to compute a diff, found %d", len(snapshots))
+	}
+
+	first := snapshots[0]
+	last := snapshots[len(snapshots)-1]
+	from := first.Profile
+	to := last.Profile
+
+	diff := Diff{
+		Login:       last.Login,
+		From:        first.TakenAt,
+		To:          last.TakenAt,
+		Snapshots:   len(snapshots),
+		Stars:       intDelta(from.Stats.TotalStars, to.Stats.TotalStars),
+		Forks:       intDelta(from.Stats.TotalForks, to.Stats.TotalForks),
+		Followers:   intDelta(from.User.Followers, to.User.Followers),
+		PublicRepos: intDelta(from.User.PublicRepos, to.User.PublicRepos),
+		Ranking: RankingDelta{
+			FromBadge:       from.Ranking.Badge,
+			ToBadge:         to.Ranking.Badge,
+			TotalScore:      floatDelta(from.Ranking.TotalScore, to.Ranking.TotalScore),
+			SocialScore:     floatDelta(from.Ranking.SocialScore, to.Ranking.SocialScore),
+			CodeScore:       floatDelta(from.Ranking.CodeScore, to.Ranking.CodeScore),
+			ActivityScore:   floatDelta(from.Ranking.ActivityScore, to.Ranking.ActivityScore),
+			InnovationScore: floatDelta(from.Ranking.InnovationScore, to.Ranking.InnovationScore),
+		},
+		Trends: Trends(snapshots),
+	}
+
+	diff.NewRepos, diff.ArchivedRepos = repoChanges(from, to)
+	diff.LanguageShift = languageShift(from.Languages, to.Languages)
+
+	return diff, nil
+}
+
+// Trends extracts the headline metrics from every snapshot for charting
+func Trends(snapshots []Snapshot) []Series {
+	series := []Series{
+		{Name: "Stars"},
+		{Name: "Forks"},
+		{Name: "Followers"},
+		{Name: "Score"},
+	}
+
+	for _, snapshot := range snapshots {
+		profile := snapshot.Profile
+		series[0].Values = append(series[0].Values, float64(profile.Stats.TotalStars))
+		series[1].Values = append(series[1].Values, float64(profile.Stats.TotalForks))
+		series[2].Values = append(series[2].Values, float64(profile.User.Followers))
+		series[3].Values = append(series[3].Values, profile.Ranking.TotalScore)
+	}
+
+	return series
+}
+
+// ParseAge parses a look-back window such as "90d", "2w" or "36h"
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	unit := value[len(value)-1]
+	var scale time.Duration
+	switch unit {
+	case 'd':
+		scale = 24 * time.Hour
+	case 'w':
+		scale = 7 * 24 * time.Hour
+	default:
+		return time.ParseDuration(value)
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return time.Duration(n) * scale, nil
+}
+
+func intDelta(from, to int) IntDelta {
+	return IntDelta{From: from, To: to, Change: to - from}
+}
+
+func floatDelta(from, to float64) FloatDelta {
+	return FloatDelta{From: from, To: to, Change: to - from}
+}
+
+// repoChanges lists repositories that appeared or became archived between two profiles
+func repoChanges(from, to *models.UserProfile) (newRepos, archivedRepos []string) {
+	previous := make(map[string]bool)
+	for _, repo := range from.Repositories {
+		previous[repo.Name] = repo.Archived
+	}
+
+	for _, repo := range to.Repositories {
+		wasArchived, existed := previous[repo.Name]
+		switch {
+		case !existed:
+			newRepos = append(newRepos, repo.Name)
+		case repo.Archived && !wasArchived:
+			archivedRepos = append(archivedRepos, repo.Name)
+		}
+	}
+
+	sort.Strings(newRepos)
+	sort.Strings(archivedRepos)
+	return newRepos, archivedRepos
+}
+
+// languageShift lists language share changes, largest movement first
+func languageShift(from, to models.LanguageStats) []LanguageShift {
+	names := make(map[string]bool)
+	for name := range from.Languages {
+		names[name] = true
+	}
+	for name := range to.Languages {
+		names[name] = true
+	}
+
+	var shifts []LanguageShift
+	for name := range names {
+		delta := floatDelta(from.Languages[name].Percentage, to.Languages[name].Percentage)
+		if math.Abs(delta.Change) < 0.05 {
+			continue
+		}
+		shifts = append(shifts, LanguageShift{Name: name, Percentage: delta})
+	}
+
+	sort.Slice(shifts, func(i, j int) bool {
+		if math.Abs(shifts[i].Percentage.Change) != math.Abs(shifts[j].Percentage.Change) {
+			return math.Abs(shifts[i].Percentage.Change) > math.Abs(shifts[j].Percentage.Change)
+		}
+		return shifts[i].Name < shifts[j].Name
+	})
+
+	return shifts
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxRecentLogins bounds the number of remembered logins
+const maxRecentLogins = 100
+
+// Recent remembers the logins that were profiled, most recent first
+type Recent struct {
+	path string
+}
+
+// DefaultRecentPath returns the default location of the recent logins file
+func DefaultRecentPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "recent.json"), nil
+}
+
+// NewRecent creates a recent logins list stored at path
+func NewRecent(path string) *Recent {
+	return &Recent{path: path}
+}
+
+// NewDefaultRecent creates a recent logins list in the default location
+func NewDefaultRecent() (*Recent, error) {
+	path, err := DefaultRecentPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewRecent(path), nil
+}
+
+// Load returns the remembered logins, most recent first
+func (r *Recent) Load() ([]string, error) {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recent logins: %w", err)
+	}
+
+	var logins []string
+	if err := json.Unmarshal(data, &logins); err != nil {
+		return nil, fmt.Errorf("failed to decode recent logins: %w", err)
+	}
+	return logins, nil
+}
+
+// Add moves login to the front of the list, dropping the oldest entries
+func (r *Recent) Add(login string) error {
+	logins, err := r.Load()
+	if err != nil {
+		// A corrupt list is replaced rather than blocking new entries
+		logins = nil
+	}
+
+	updated := []string{login}
+	for _, existing := range logins {
+		if !strings.EqualFold(existing, login) {
+			updated = append(updated, existing)
+		}
+	}
+	if len(updated) > maxRecentLogins {
+		updated = updated[:maxRecentLogins]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	data, err := json.Marshal(updated)
+	if err != nil {
+		return fmt.Errorf("failed to encode recent logins: %w", err)
+	}
+
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write recent logins: %w", err)
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		return fmt.Errorf("failed to write recent logins: %w", err)
+	}
+	return nil
+}
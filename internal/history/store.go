@@ -0,0 +1,236 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github-profiler/internal/models"
+)
+
+// snapshotTimeLayout is used for snapshot file names so they sort chronologically
+const snapshotTimeLayout = "20060102T150405.000000000Z"
+
+// snapshotDayLen is the length of the date at the start of a snapshot file name
+const snapshotDayLen = len("20060102")
+
+// maxSnapshots bounds the snapshots kept per user, about two years of daily ones
+const maxSnapshots = 730
+
+// Snapshot is a user profile captured at a point in time
+type Snapshot struct {
+	Login   string              `json:"login"`
+	TakenAt time.Time           `json:"taken_at"`
+	Profile *models.UserProfile `json:"profile"`
+}
+
+// Store persists profile snapshots on the local filesystem
+type Store struct {
+	dir string
+}
+
+// DefaultDir returns the default snapshot directory following the XDG base directory spec
+func DefaultDir() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snapshots"), nil
+}
+
+// dataDir returns the application data directory following the XDG base directory spec
+func dataDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "github-profiler"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "github-profiler"), nil
+}
+
+// NewStore creates a snapshot store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// NewDefaultStore creates a snapshot store in the default directory
+func NewDefaultStore() (*Store, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(dir), nil
+}
+
+// Dir returns the root directory of the store
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save writes the profile as a new snapshot. Only the latest snapshot of each
+// day is kept, and at most maxSnapshots per user, so repeated runs and watch
+// mode do not grow the history without bound.
+func (s *Store) Save(profile *models.UserProfile) (Snapshot, error) {
+	if profile == nil || profile.User.Login == "" {
+		return Snapshot{}, fmt.Errorf("cannot snapshot an empty profile")
+	}
+
+	snapshot := Snapshot{
+		Login:   profile.User.Login,
+		TakenAt: time.Now().UTC(),
+		Profile: profile,
+	}
+
+	userDir := s.userDir(snapshot.Login)
+	if err := os.MkdirAll(userDir, 0o755); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated snapshot
+	path := filepath.Join(userDir, snapshot.TakenAt.Format(snapshotTimeLayout)+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := prune(userDir, filepath.Base(path)); err != nil {
+		return Snapshot{}, err
+	}
+
+	return snapshot, nil
+}
+
+// prune removes the snapshots taken earlier on the same day as latest and the
+// oldest ones beyond maxSnapshots
+func prune(userDir, latest string) error {
+	entries, err := os.ReadDir(userDir)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	// ReadDir sorts by name, which is chronological
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" || name == latest {
+			continue
+		}
+		if len(name) >= snapshotDayLen && name[:snapshotDayLen] == latest[:snapshotDayLen] {
+			if err := os.Remove(filepath.Join(userDir, name)); err != nil {
+				return fmt.Errorf("failed to remove snapshot: %w", err)
+			}
+			continue
+		}
+		names = append(names, name)
+	}
+
+	// The latest snapshot counts towards the limit too
+	for len(names)+1 > maxSnapshots {
+		if err := os.Remove(filepath.Join(userDir, names[0])); err != nil {
+			return fmt.Errorf("failed to remove snapshot: %w", err)
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// List returns every stored snapshot for login, oldest first
+func (s *Store) List(login string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.userDir(login))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		snapshot, err := readSnapshot(filepath.Join(s.userDir(login), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].TakenAt.Before(snapshots[j].TakenAt)
+	})
+
+	return snapshots, nil
+}
+
+// Since returns the snapshots needed to describe changes since the given time.
+// The result starts with the latest snapshot taken at or before since, when one
+// exists, so the baseline covers the whole window.
+func (s *Store) Since(login string, since time.Time) ([]Snapshot, error) {
+	snapshots, err := s.List(login)
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	for i, snapshot := range snapshots {
+		if snapshot.TakenAt.After(since) {
+			break
+		}
+		start = i
+	}
+
+	return snapshots[start:], nil
+}
+
+// Logins returns every login that has at least one snapshot
+func (s *Store) Logins() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	var logins []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			logins = append(logins, entry.Name())
+		}
+	}
+	return logins, nil
+}
+
+func (s *Store) userDir(login string) string {
+	return filepath.Join(s.dir, strings.ToLower(login))
+}
+
+func readSnapshot(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to decode snapshot %s: %w", filepath.Base(path), err)
+	}
+	return snapshot, nil
+}
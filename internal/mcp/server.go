@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github-profiler/internal/models"
+)
+
+// maxMessageSize bounds a single JSON-RPC message read from the client
+const maxMessageSize = 4 << 20
+
+// ProfileSource fetches user profiles for the tools
+type ProfileSource interface {
+	Profile(ctx context.Context, login string) (*models.UserProfile, error)
+}
+
+// Server answers MCP requests about GitHub profiles. It speaks JSON-RPC 2.0
+// with one message per line, as the stdio transport requires.
+type Server struct {
+	profiles ProfileSource
+	version  string
+}
+
+// NewServer creates a server whose tools fetch profiles from profiles
+func NewServer(profiles ProfileSource, version string) *Server {
+	return &Server{profiles: profiles, version: version}
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is done, which are both a normal stop. Requests are answered in order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+
+	// Read in the background so an idle server still stops when ctx is done
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+		for scanner.Scan() {
+			select {
+			case lines <- bytes.Clone(scanner.Bytes()):
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	for {
+		var line []byte
+		select {
+		case <-ctx.Done():
+			return nil
+		case l, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-readErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			line = l
+		}
+		if len(line) == 0 {
+			continue
+		}
+
+		resp, ok := s.handle(ctx, line)
+		if !ok {
+			continue
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
+
+// handle answers one message. It returns false for notifications, which get no response.
+func (s *Server) handle(ctx context.Context, line []byte) (response, bool) {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(nil, &rpcError{Code: codeParseError, Message: "invalid JSON: " + err.Error()}), true
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, &rpcError{Code: codeInvalidRequest, Message: "expected a JSON-RPC 2.0 request"}), !req.isNotification()
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if req.isNotification() {
+		return response{}, false
+	}
+	if err != nil {
+		return errorResponse(req.ID, err), true
+	}
+	return response{JSONRPC: "2.0", ID: req.ID, Result: result}, true
+}
+
+// dispatch runs the method named in req
+func (s *Server) dispatch(ctx context.Context, req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+
+		// Agree on the client's version when we speak it, otherwise offer ours
+		version := protocolVersion
+		if slices.Contains(supportedVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return initializeResult{
+			ProtocolVersion: version,
+			Capabilities:    map[string]any{"tools": map[string]any{"listChanged": false}},
+			ServerInfo:      serverInfo{Name: "github-profiler", Version: s.version},
+			Instructions:    "Tools for analysing public GitHub user profiles: activity, languages, repositories and a developer ranking with its explanation.",
+		}, nil
+
+	case "ping":
+		return struct{}{}, nil
+
+	case "tools/list":
+		return listToolsResult{Tools: toolList()}, nil
+
+	case "tools/call":
+		var params callToolParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.callTool(ctx, params)
+
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func errorResponse(id json.RawMessage, err *rpcError) response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return response{JSONRPC: "2.0", ID: id, Error: err}
+}
@@ -0,0 +1,303 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github-profiler/internal/models"
+	"github-profiler/internal/services"
+)
+
+// Bounds on the tool arguments
+const (
+	maxCompared      = 10
+	summaryRepos     = 10
+	summaryLanguages = 5
+)
+
+var loginSchema = map[string]any{
+	"type":        "string",
+	"description": "GitHub login, e.g. torvalds",
+}
+
+// toolList describes the tools the server offers
+func toolList() []tool {
+	loginInput := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"login": loginSchema},
+		"required":   []string{"login"},
+	}
+
+	return []tool{
+		{
+			Name:        "get_profile",
+			Title:       "Get GitHub profile",
+			Description: "Summarise a GitHub user: account details, repository statistics, activity, top languages, top repositories by stars and the developer ranking.",
+			InputSchema: loginInput,
+		},
+		{
+			Name:        "compare_profiles",
+			Title:       "Compare GitHub profiles",
+			Description: fmt.Sprintf("Compare 2 to %d GitHub users side by side: followers, repositories, stars, forks, top languages and ranking, with the leader of each metric and the languages they share.", maxCompared),
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"logins": map[string]any{
+						"type":        "array",
+						"items":       loginSchema,
+						"minItems":    2,
+						"maxItems":    maxCompared,
+						"description": "GitHub logins to compare",
+					},
+				},
+				"required": []string{"logins"},
+			},
+		},
+		{
+			Name:        "get_language_breakdown",
+			Title:       "Get language breakdown",
+			Description: "List the programming languages across a GitHub user's public repositories, by bytes of code, with their share and repository count.",
+			InputSchema: loginInput,
+		},
+		{
+			Name:        "explain_ranking",
+			Title:       "Explain developer ranking",
+			Description: "Explain a GitHub user's developer ranking: the points each scoring rule awarded, the component scores against their maximums, and hints on what would raise the score most.",
+			InputSchema: loginInput,
+		},
+	}
+}
+
+// profileSummary is the get_profile result, trimmed from models.UserProfile
+// to what is useful in a conversation
+type profileSummary struct {
+	Login        string                `json:"login"`
+	Name         string                `json:"name,omitempty"`
+	Bio          string                `json:"bio,omitempty"`
+	Company      string                `json:"company,omitempty"`
+	Location     string                `json:"location,omitempty"`
+	Blog         string                `json:"blog,omitempty"`
+	URL          string                `json:"html_url"`
+	Followers    int                   `json:"followers"`
+	Following    int                   `json:"following"`
+	PublicRepos  int                   `json:"public_repos"`
+	CreatedAt    string                `json:"created_at,omitempty"`
+	Stats        models.ProfileStats   `json:"stats"`
+	Activity     models.ActivityStats  `json:"activity"`
+	TopLanguages []models.LanguageInfo `json:"top_languages"`
+	TopRepos     []repoSummary         `json:"top_repositories"`
+	Ranking      models.RankingInfo    `json:"ranking"`
+}
+
+type repoSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Stars       int    `json:"stars"`
+	Forks       int    `json:"forks"`
+	Fork        bool   `json:"fork,omitempty"`
+	Archived    bool   `json:"archived,omitempty"`
+	PushedAt    string `json:"pushed_at,omitempty"`
+}
+
+type languageBreakdown struct {
+	Login      string                `json:"login"`
+	TotalBytes int                   `json:"total_bytes"`
+	Languages  []models.LanguageInfo `json:"languages"`
+}
+
+type rankingExplanation struct {
+	Login   string             `json:"login"`
+	Ranking models.RankingInfo `json:"ranking"`
+}
+
+// callTool runs the named tool. Failures of the tool itself are reported in
+// the result so the model can see them; only unknown tools are protocol errors.
+func (s *Server) callTool(ctx context.Context, params callToolParams) (any, *rpcError) {
+	var (
+		result any
+		err    error
+	)
+
+	switch params.Name {
+	case "get_profile":
+		result, err = withLogin(ctx, params.Arguments, s.getProfile)
+	case "get_language_breakdown":
+		result, err = withLogin(ctx, params.Arguments, s.getLanguageBreakdown)
+	case "explain_ranking":
+		result, err = withLogin(ctx, params.Arguments, s.explainRanking)
+	case "compare_profiles":
+		result, err = s.compareProfiles(ctx, params.Arguments)
+	default:
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+
+	if err != nil {
+		return callToolResult{
+			Content: []textContent{{Type: "text", Text: err.Error()}},
+			IsError: true,
+		}, nil
+	}
+
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return callToolResult{
+		Content:           []textContent{{Type: "text", Text: string(text)}},
+		StructuredContent: result,
+	}, nil
+}
+
+// withLogin decodes a {"login": ...} argument object and calls fn with it
+func withLogin(ctx context.Context, arguments json.RawMessage, fn func(ctx context.Context, login string) (any, error)) (any, error) {
+	var args struct {
+		Login string `json:"login"`
+	}
+	if len(arguments) > 0 {
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	if args.Login == "" {
+		return nil, fmt.Errorf("login is required")
+	}
+	return fn(ctx, args.Login)
+}
+
+func (s *Server) getProfile(ctx context.Context, login string) (any, error) {
+	profile, err := s.profiles.Profile(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	user := profile.User
+	summary := profileSummary{
+		Login:        user.Login,
+		Name:         user.Name,
+		Bio:          user.Bio,
+		Company:      user.Company,
+		Location:     user.Location,
+		Blog:         user.Blog,
+		URL:          user.HTMLURL,
+		Followers:    user.Followers,
+		Following:    user.Following,
+		PublicRepos:  user.PublicRepos,
+		Stats:        profile.Stats,
+		Activity:     profile.Activity,
+		TopLanguages: sortedLanguages(profile.Languages),
+		TopRepos:     topRepos(profile, summaryRepos),
+		Ranking:      profile.Ranking,
+	}
+	if !user.CreatedAt.IsZero() {
+		summary.CreatedAt = user.CreatedAt.Format("2006-01-02")
+	}
+	if len(summary.TopLanguages) > summaryLanguages {
+		summary.TopLanguages = summary.TopLanguages[:summaryLanguages]
+	}
+
+	// The explanation has its own tool
+	summary.Ranking.Explanation = nil
+	return summary, nil
+}
+
+func (s *Server) getLanguageBreakdown(ctx context.Context, login string) (any, error) {
+	profile, err := s.profiles.Profile(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	return languageBreakdown{
+		Login:      profile.User.Login,
+		TotalBytes: profile.Languages.TotalBytes,
+		Languages:  sortedLanguages(profile.Languages),
+	}, nil
+}
+
+func (s *Server) explainRanking(ctx context.Context, login string) (any, error) {
+	profile, err := s.profiles.Profile(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Ranking.Explanation == nil {
+		return nil, fmt.Errorf("no ranking explanation is available for %s", login)
+	}
+	return rankingExplanation{Login: profile.User.Login, Ranking: profile.Ranking}, nil
+}
+
+func (s *Server) compareProfiles(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Logins []string `json:"logins"`
+	}
+	if len(arguments) > 0 {
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	if len(args.Logins) < 2 || len(args.Logins) > maxCompared {
+		return nil, fmt.Errorf("compare needs between 2 and %d logins", maxCompared)
+	}
+
+	profiles := make([]*models.UserProfile, len(args.Logins))
+	errs := make([]error, len(args.Logins))
+	var wg sync.WaitGroup
+	for i, login := range args.Logins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profiles[i], errs[i] = s.profiles.Profile(ctx, login)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", args.Logins[i], err)
+		}
+	}
+	return services.Compare(profiles), nil
+}
+
+// sortedLanguages lists languages by size, largest first
+func sortedLanguages(stats models.LanguageStats) []models.LanguageInfo {
+	langs := make([]models.LanguageInfo, 0, len(stats.Languages))
+	for _, lang := range stats.Languages {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if langs[i].Bytes != langs[j].Bytes {
+			return langs[i].Bytes > langs[j].Bytes
+		}
+		return langs[i].Name < langs[j].Name
+	})
+	return langs
+}
+
+// topRepos lists the n most starred repositories
+func topRepos(profile *models.UserProfile, n int) []repoSummary {
+	repos := make([]repoSummary, 0, len(profile.Repositories))
+	for _, repo := range profile.Repositories {
+		summary := repoSummary{
+			Name:        repo.Name,
+			Description: repo.Description,
+			Language:    repo.Language,
+			Stars:       repo.StargazersCount,
+			Forks:       repo.ForksCount,
+			Fork:        repo.Fork,
+			Archived:    repo.Archived,
+		}
+		if !repo.PushedAt.IsZero() {
+			summary.PushedAt = repo.PushedAt.Format("2006-01-02")
+		}
+		repos = append(repos, summary)
+	}
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].Stars > repos[j].Stars
+	})
+	if len(repos) > n {
+		repos = repos[:n]
+	}
+	return repos
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// User is a GitHub user account. Fields keep the names of the GitHub REST API.
+type User struct {
+	Login           string    `json:"login"`
+	ID              int64     `json:"id,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Bio             string    `json:"bio,omitempty"`
+	Company         string    `json:"company,omitempty"`
+	Location        string    `json:"location,omitempty"`
+	Blog            string    `json:"blog,omitempty"`
+	Email           string    `json:"email,omitempty"`
+	TwitterUsername string    `json:"twitter_username,omitempty"`
+	AvatarURL       string    `json:"avatar_url,omitempty"`
+	HTMLURL         string    `json:"html_url,omitempty"`
+	Type            string    `json:"type,omitempty"`
+	PublicRepos     int       `json:"public_repos"`
+	PublicGists     int       `json:"public_gists"`
+	Followers       int       `json:"followers"`
+	Following       int       `json:"following"`
+	CreatedAt       time.Time `json:"created_at,omitzero"`
+	UpdatedAt       time.Time `json:"updated_at,omitzero"`
+}
+
+// Organization is a GitHub organization account
+type Organization struct {
+	Login       string    `json:"login"`
+	ID          int64     `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Company     string    `json:"company,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	Blog        string    `json:"blog,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	AvatarURL   string    `json:"avatar_url,omitempty"`
+	HTMLURL     string    `json:"html_url,omitempty"`
+	PublicRepos int       `json:"public_repos"`
+	Followers   int       `json:"followers"`
+	CreatedAt   time.Time `json:"created_at,omitzero"`
+}
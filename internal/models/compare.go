@@ -0,0 +1,27 @@
+package models
+
+// Comparison sets several profiles side by side
+type Comparison struct {
+	Users []ComparedUser `json:"users"`
+
+	// Leaders maps each compared metric to the login with the highest value
+	Leaders map[string]string `json:"leaders"`
+
+	// SharedLanguages are the languages every compared user writes
+	SharedLanguages []string `json:"shared_languages"`
+}
+
+// ComparedUser is the summary of one profile in a comparison
+type ComparedUser struct {
+	Login            string   `json:"login"`
+	Name             string   `json:"name,omitempty"`
+	Followers        int      `json:"followers"`
+	PublicRepos      int      `json:"public_repos"`
+	TotalStars       int      `json:"total_stars"`
+	TotalForks       int      `json:"total_forks"`
+	TopLanguages     []string `json:"top_languages"`
+	Badge            string   `json:"badge"`
+	TotalScore       float64  `json:"total_score"`
+	Percentile       *float64 `json:"percentile,omitempty"`
+	PercentileSource string   `json:"percentile_source,omitempty"`
+}
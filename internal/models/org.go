@@ -0,0 +1,11 @@
+package models
+
+// OrgProfile represents an organization with statistics over its public repositories
+type OrgProfile struct {
+	SchemaVersion string        `json:"schema_version"`
+	Organization  Organization  `json:"organization"`
+	Repositories  []*Repository `json:"repositories"`
+	Languages     LanguageStats `json:"languages"`
+	Stats         ProfileStats  `json:"stats"`
+	Members       []string      `json:"members"`
+}
@@ -1,17 +1,19 @@
 package models
 
-import (
-	"github.com/google/go-github/v73/github"
-)
+// SchemaVersion versions the JSON form of the profile types and is documented
+// by the JSON Schema in pkg/profiler. Version 1 embedded go-github types;
+// version 2 always reported a percentile, 0 when there was none.
+const SchemaVersion = "3"
 
 // UserProfile represents the comprehensive user profile data
 type UserProfile struct {
-	User         *github.User         `json:"user"`
-	Repositories []*github.Repository `json:"repositories"`
-	Languages    LanguageStats        `json:"languages"`
-	Stats        ProfileStats         `json:"stats"`
-	Activity     ActivityStats        `json:"activity"`
-	Ranking      RankingInfo          `json:"ranking"`
+	SchemaVersion string        `json:"schema_version"`
+	User          User          `json:"user"`
+	Repositories  []*Repository `json:"repositories"`
+	Languages     LanguageStats `json:"languages"`
+	Stats         ProfileStats  `json:"stats"`
+	Activity      ActivityStats `json:"activity"`
+	Ranking       RankingInfo   `json:"ranking"`
 }
 
 // LanguageStats represents programming language usage statistics
@@ -55,45 +57,58 @@ type ActivityStats struct {
 
 // RankingInfo represents the developer ranking system
 type RankingInfo struct {
-	OverallRank     string  `json:"overall_rank"`
-	Badge           string  `json:"badge"`
-	TotalScore      float64 `json:"total_score"`
-	Percentile      float64 `json:"percentile"`
-	SocialScore     float64 `json:"social_score"`
-	CodeScore       float64 `json:"code_score"`
-	ActivityScore   float64 `json:"activity_score"`
-	InnovationScore float64 `json:"innovation_score"`
+	OverallRank      string      `json:"overall_rank"`
+	Badge            string      `json:"badge"`
+	TotalScore       float64     `json:"total_score"`
+	Percentile       *float64    `json:"percentile,omitempty"`
+	PercentileSource string      `json:"percentile_source,omitempty"`
+	SocialScore      float64     `json:"social_score"`
+	CodeScore        float64     `json:"code_score"`
+	ActivityScore    float64     `json:"activity_score"`
+	InnovationScore  float64     `json:"innovation_score"`
+	ScoringModel     string      `json:"scoring_model"`
+	MaxScores        ScoreLimits `json:"max_scores"`
+
+	Explanation *RankingExplanation `json:"explanation,omitempty"`
+}
+
+// ScoreLimits holds the highest score each ranking component can reach
+type ScoreLimits struct {
+	Total      float64 `json:"total"`
+	Social     float64 `json:"social"`
+	Code       float64 `json:"code"`
+	Activity   float64 `json:"activity"`
+	Innovation float64 `json:"innovation"`
+}
+
+// RankingExplanation records every scoring rule that fired and how the score could improve
+type RankingExplanation struct {
+	Rules []RuleContribution `json:"rules"`
+	Hints []ScoreHint        `json:"hints"`
 }
 
-// RankTier represents different developer levels
-type RankTier struct {
-	Name        string
-	Badge       string
-	MinScore    float64
-	MaxScore    float64
-	Description string
+// RuleContribution is a single step of the score computation
+type RuleContribution struct {
+	Component   string  `json:"component"`
+	Metric      string  `json:"metric,omitempty"`
+	Input       float64 `json:"input"`
+	Points      float64 `json:"points"`
+	Capped      bool    `json:"capped"`
+	Description string  `json:"description"`
 }
 
-// GetRankTiers returns all available rank tiers
-func GetRankTiers() []RankTier {
-	return []RankTier{
-		{"Elite Developer", "ELITE", 90, 100, "Industry leader and innovator"},
-		{"Senior Developer", "SENIOR", 80, 89, "Experienced professional"},
-		{"Experienced Developer", "EXPERIENCED", 70, 79, "Skilled practitioner"},
-		{"Active Developer", "ACTIVE", 60, 69, "Regular contributor"},
-		{"Growing Developer", "GROWING", 50, 59, "Developing skills"},
-		{"Junior Developer", "JUNIOR", 30, 49, "Learning and improving"},
-		{"Beginner", "BEGINNER", 0, 29, "Just starting out"},
-	}
+// ScoreHint suggests a change that would raise the total score
+type ScoreHint struct {
+	Component string  `json:"component"`
+	Metric    string  `json:"metric"`
+	Gain      float64 `json:"gain"`
+	Message   string  `json:"message"`
 }
 
-// GetRankByScore returns the appropriate rank for a given score
-func GetRankByScore(score float64) RankTier {
-	tiers := GetRankTiers()
-	for _, tier := range tiers {
-		if score >= tier.MinScore && score <= tier.MaxScore {
-			return tier
-		}
-	}
-	return tiers[len(tiers)-1] // Return beginner as fallback
+// RepositoryDetail holds the extra data shown when drilling into a repository
+type RepositoryDetail struct {
+	Repository    *Repository    `json:"repository"`
+	Languages     map[string]int `json:"languages"`
+	LatestRelease *Release       `json:"latest_release,omitempty"`
+	ReadmeExcerpt string         `json:"readme_excerpt"`
 }
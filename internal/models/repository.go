@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+// Repository is a GitHub repository. Fields keep the names of the GitHub REST API.
+type Repository struct {
+	ID              int64     `json:"id,omitempty"`
+	Name            string    `json:"name"`
+	FullName        string    `json:"full_name,omitempty"`
+	Owner           Owner     `json:"owner,omitzero"`
+	Description     string    `json:"description,omitempty"`
+	HTMLURL         string    `json:"html_url,omitempty"`
+	Homepage        string    `json:"homepage,omitempty"`
+	Language        string    `json:"language,omitempty"`
+	Topics          []string  `json:"topics,omitempty"`
+	License         *License  `json:"license,omitempty"`
+	DefaultBranch   string    `json:"default_branch,omitempty"`
+	Fork            bool      `json:"fork"`
+	Private         bool      `json:"private"`
+	Archived        bool      `json:"archived"`
+	StargazersCount int       `json:"stargazers_count"`
+	WatchersCount   int       `json:"watchers_count"`
+	ForksCount      int       `json:"forks_count"`
+	OpenIssuesCount int       `json:"open_issues_count"`
+	Size            int       `json:"size"`
+	CreatedAt       time.Time `json:"created_at,omitzero"`
+	UpdatedAt       time.Time `json:"updated_at,omitzero"`
+	PushedAt        time.Time `json:"pushed_at,omitzero"`
+}
+
+// Owner is the account a repository belongs to
+type Owner struct {
+	Login string `json:"login"`
+}
+
+// License is the license GitHub detected for a repository
+type License struct {
+	Key    string `json:"key,omitempty"`
+	Name   string `json:"name"`
+	SPDXID string `json:"spdx_id,omitempty"`
+}
+
+// Release is a published repository release
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name,omitempty"`
+	HTMLURL     string    `json:"html_url,omitempty"`
+	Prerelease  bool      `json:"prerelease,omitempty"`
+	PublishedAt time.Time `json:"published_at,omitzero"`
+}
@@ -0,0 +1,268 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github-profiler/internal/charts"
+	"github-profiler/internal/history"
+	"github-profiler/internal/models"
+)
+
+// htmlFuncs are the helpers available to the HTML templates
+var htmlFuncs = template.FuncMap{
+	"sparkline": sparklineSVG,
+	"languages": sortedLanguages,
+	"langColor": charts.LanguageColor,
+	"timeline":  timelineBars,
+	"topRepos":  topRepositories,
+	"signed":    signed,
+	"signedf":   signedFloat,
+	"date": func(t time.Time) string {
+		return t.Format("Jan 2, 2006")
+	},
+	"mb": func(kb int64) string {
+		return fmt.Sprintf("%.1f", float64(kb)/1024)
+	},
+}
+
+var profileTemplate = template.Must(template.New("profile").Funcs(htmlFuncs).Parse(baseStyle + profileHTML))
+
+var diffTemplate = template.Must(template.New("diff").Funcs(htmlFuncs).Parse(baseStyle + diffHTML))
+
+// WriteHTML renders a profile as a standalone HTML report. When snapshots are
+// given, a trend section with sparklines is included.
+func WriteHTML(w io.Writer, profile *models.UserProfile, snapshots []history.Snapshot) error {
+	data := struct {
+		Profile   *models.UserProfile
+		Trends    []history.Series
+		Generated time.Time
+	}{
+		Profile:   profile,
+		Generated: time.Now(),
+	}
+	if len(snapshots) > 1 {
+		data.Trends = history.Trends(snapshots)
+	}
+
+	return profileTemplate.ExecuteTemplate(w, "profile", data)
+}
+
+// WriteDiffHTML renders a snapshot diff as a standalone HTML report
+func WriteDiffHTML(w io.Writer, diff history.Diff) error {
+	return diffTemplate.ExecuteTemplate(w, "diff", diff)
+}
+
+// sparklineSVG renders an inline SVG sparkline
+func sparklineSVG(values []float64) template.HTML {
+	const width, height = 160.0, 32.0
+	return template.HTML(fmt.Sprintf(
+		`<svg class="spark" width="%.0f" height="%.0f" viewBox="-2 -2 %.0f %.0f"><polyline fill="none" stroke="currentColor" stroke-width="2" points="%s"/></svg>`,
+		width, height, width+4, height+4, charts.SparklinePoints(values, width, height)))
+}
+
+// sortedLanguages returns languages ordered by share, largest first
+func sortedLanguages(stats models.LanguageStats) []models.LanguageInfo {
+	langs := make([]models.LanguageInfo, 0, len(stats.Languages))
+	for _, lang := range stats.Languages {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if langs[i].Percentage != langs[j].Percentage {
+			return langs[i].Percentage > langs[j].Percentage
+		}
+		return langs[i].Name < langs[j].Name
+	})
+	return langs
+}
+
+// timelineBar is a creation timeline year scaled against the busiest year
+type timelineBar struct {
+	Year       int
+	Count      int
+	Percentage float64
+}
+
+// timelineBars scales the creation timeline for drawing as bars
+func timelineBars(entries []models.TimelineEntry) []timelineBar {
+	peak := 0
+	for _, entry := range entries {
+		peak = max(peak, entry.Count)
+	}
+
+	bars := make([]timelineBar, len(entries))
+	for i, entry := range entries {
+		bars[i] = timelineBar{Year: entry.Year, Count: entry.Count}
+		if peak > 0 {
+			bars[i].Percentage = float64(entry.Count) / float64(peak) * 100
+		}
+	}
+	return bars
+}
+
+// topRepositories returns up to n original repositories ordered by stars
+func topRepositories(repos []*models.Repository, n int) []*models.Repository {
+	var original []*models.Repository
+	for _, repo := range repos {
+		if !repo.Fork {
+			original = append(original, repo)
+		}
+	}
+	sort.SliceStable(original, func(i, j int) bool {
+		return original[i].StargazersCount > original[j].StargazersCount
+	})
+	if len(original) > n {
+		original = original[:n]
+	}
+	return original
+}
+
+func signed(n int) string {
+	return fmt.Sprintf("%+d", n)
+}
+
+func signedFloat(f float64) string {
+	return fmt.Sprintf("%+.1f", f)
+}
+
+const baseStyle = `{{define "style"}}<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; background: #0d1117; color: #c9d1d9; }
+main { max-width: 960px; margin: 0 auto; padding: 32px 16px; }
+h1, h2 { color: #5fd7d7; font-weight: 600; }
+h2 { border-bottom: 1px solid #30363d; padding-bottom: 6px; margin-top: 32px; }
+.muted { color: #8b949e; }
+.grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 12px; }
+.card { border: 1px solid #30363d; border-radius: 8px; padding: 12px 16px; }
+.card .value { font-size: 1.6em; font-weight: 600; }
+table { width: 100%; border-collapse: collapse; }
+td, th { text-align: left; padding: 6px 8px; border-bottom: 1px solid #21262d; }
+.bar { background: #21262d; border-radius: 4px; height: 10px; width: 100%; }
+.bar span { display: block; background: #5fd7d7; border-radius: 4px; height: 10px; }
+.badge { display: inline-block; background: #5fd7d7; color: #0d1117; font-weight: 700; padding: 2px 10px; border-radius: 4px; }
+.spark { color: #5fd7d7; vertical-align: middle; }
+.up { color: #3fb950; }
+.down { color: #f85149; }
+</style>{{end}}`
+
+const profileHTML = `{{define "profile"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>GitHub Profile Analysis - {{.Profile.User.Login}}</title>
+{{template "style"}}
+</head>
+<body>
+<main>
+{{with .Profile}}
+<h1>{{.User.Name}} <span class="muted">({{.User.Login}})</span></h1>
+<p>{{.User.Bio}}</p>
+<p class="muted">{{with .User.Company}}{{.}} &middot; {{end}}{{with .User.Location}}{{.}} &middot; {{end}}Joined {{date .User.CreatedAt}}</p>
+
+<h2>Overview</h2>
+<div class="grid">
+<div class="card"><div class="muted">Public Repos</div><div class="value">{{.User.PublicRepos}}</div></div>
+<div class="card"><div class="muted">Followers</div><div class="value">{{.User.Followers}}</div></div>
+<div class="card"><div class="muted">Total Stars</div><div class="value">{{.Stats.TotalStars}}</div></div>
+<div class="card"><div class="muted">Total Forks</div><div class="value">{{.Stats.TotalForks}}</div></div>
+<div class="card"><div class="muted">Repository Size</div><div class="value">{{mb .Stats.TotalSize}} MB</div></div>
+<div class="card"><div class="muted">Avg Stars/Repo</div><div class="value">{{printf "%.1f" .Stats.AvgStarsPerRepo}}</div></div>
+</div>
+{{end}}
+
+{{if .Trends}}
+<h2>Trends</h2>
+<table>
+{{range .Trends}}<tr><td>{{.Name}}</td><td>{{sparkline .Values}}</td><td>{{printf "%.1f" .Latest}}</td><td class="{{if ge .Change 0.0}}up{{else}}down{{end}}">{{signedf .Change}}</td></tr>
+{{end}}</table>
+{{end}}
+
+{{with .Profile}}
+<h2>Repositories</h2>
+<table>
+<tr><th>Name</th><th>Language</th><th>Stars</th><th>Forks</th><th>Updated</th></tr>
+{{range topRepos .Repositories 10}}<tr><td>{{.Name}}<div class="muted">{{.Description}}</div></td><td>{{.Language}}</td><td>{{.StargazersCount}}</td><td>{{.ForksCount}}</td><td>{{date .UpdatedAt}}</td></tr>
+{{end}}</table>
+
+<h2>Languages</h2>
+<table>
+{{range languages .Languages}}<tr><td>{{.Name}}</td><td style="width:50%"><div class="bar"><span style="width:{{printf "%.1f" .Percentage}}%;background:{{langColor .Name}}"></span></div></td><td>{{printf "%.1f" .Percentage}}%</td><td class="muted">{{.RepoCount}} repos</td></tr>
+{{end}}</table>
+
+<h2>Activity</h2>
+<p>Contribution Score: {{printf "%.1f" .Activity.ContributionScore}}</p>
+<table>
+{{range timeline .Stats.CreationTimeline}}<tr><td>{{.Year}}</td><td style="width:50%"><div class="bar"><span style="width:{{printf "%.1f" .Percentage}}%"></span></div></td><td>{{.Count}} repositories</td></tr>
+{{end}}</table>
+
+<h2>Ranking</h2>
+<p><span class="badge">{{.Ranking.Badge}}</span> {{.Ranking.OverallRank}}</p>
+<table>
+<tr><td>Total Score</td><td>{{printf "%.1f" .Ranking.TotalScore}}</td></tr>
+<tr><td>Social Score</td><td>{{printf "%.1f" .Ranking.SocialScore}}</td></tr>
+<tr><td>Code Score</td><td>{{printf "%.1f" .Ranking.CodeScore}}</td></tr>
+<tr><td>Activity Score</td><td>{{printf "%.1f" .Ranking.ActivityScore}}</td></tr>
+<tr><td>Innovation Score</td><td>{{printf "%.1f" .Ranking.InnovationScore}}</td></tr>
+</table>
+{{end}}
+
+<p class="muted">Generated by GitHub Profiler on {{date .Generated}}</p>
+</main>
+</body>
+</html>
+{{end}}`
+
+const diffHTML = `{{define "diff"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>GitHub Profile Changes - {{.Login}}</title>
+{{template "style"}}
+</head>
+<body>
+<main>
+<h1>Changes for {{.Login}}</h1>
+<p class="muted">{{date .From}} &rarr; {{date .To}} &middot; {{.Snapshots}} snapshots</p>
+
+<h2>Trends</h2>
+<table>
+{{range .Trends}}<tr><td>{{.Name}}</td><td>{{sparkline .Values}}</td><td>{{printf "%.1f" .Latest}}</td><td class="{{if ge .Change 0.0}}up{{else}}down{{end}}">{{signedf .Change}}</td></tr>
+{{end}}</table>
+
+<h2>Metrics</h2>
+<table>
+<tr><th>Metric</th><th>From</th><th>To</th><th>Change</th></tr>
+<tr><td>Stars</td><td>{{.Stars.From}}</td><td>{{.Stars.To}}</td><td>{{signed .Stars.Change}}</td></tr>
+<tr><td>Forks</td><td>{{.Forks.From}}</td><td>{{.Forks.To}}</td><td>{{signed .Forks.Change}}</td></tr>
+<tr><td>Followers</td><td>{{.Followers.From}}</td><td>{{.Followers.To}}</td><td>{{signed .Followers.Change}}</td></tr>
+<tr><td>Public Repos</td><td>{{.PublicRepos.From}}</td><td>{{.PublicRepos.To}}</td><td>{{signed .PublicRepos.Change}}</td></tr>
+</table>
+
+<h2>Repositories</h2>
+<p>New: {{range $i, $r := .NewRepos}}{{if $i}}, {{end}}{{$r}}{{else}}<span class="muted">none</span>{{end}}</p>
+<p>Archived: {{range $i, $r := .ArchivedRepos}}{{if $i}}, {{end}}{{$r}}{{else}}<span class="muted">none</span>{{end}}</p>
+
+<h2>Language Share</h2>
+<table>
+{{range .LanguageShift}}<tr><td>{{.Name}}</td><td>{{printf "%.1f" .Percentage.From}}%</td><td>{{printf "%.1f" .Percentage.To}}%</td><td>{{signedf .Percentage.Change}}</td></tr>
+{{else}}<tr><td class="muted">No significant change</td></tr>
+{{end}}</table>
+
+<h2>Ranking</h2>
+{{with .Ranking}}
+<p><span class="badge">{{.FromBadge}}</span> &rarr; <span class="badge">{{.ToBadge}}</span></p>
+<table>
+<tr><th>Component</th><th>From</th><th>To</th><th>Change</th></tr>
+<tr><td>Total Score</td><td>{{printf "%.1f" .TotalScore.From}}</td><td>{{printf "%.1f" .TotalScore.To}}</td><td>{{signedf .TotalScore.Change}}</td></tr>
+<tr><td>Social Score</td><td>{{printf "%.1f" .SocialScore.From}}</td><td>{{printf "%.1f" .SocialScore.To}}</td><td>{{signedf .SocialScore.Change}}</td></tr>
+<tr><td>Code Score</td><td>{{printf "%.1f" .CodeScore.From}}</td><td>{{printf "%.1f" .CodeScore.To}}</td><td>{{signedf .CodeScore.Change}}</td></tr>
+<tr><td>Activity Score</td><td>{{printf "%.1f" .ActivityScore.From}}</td><td>{{printf "%.1f" .ActivityScore.To}}</td><td>{{signedf .ActivityScore.Change}}</td></tr>
+<tr><td>Innovation Score</td><td>{{printf "%.1f" .InnovationScore.From}}</td><td>{{printf "%.1f" .InnovationScore.To}}</td><td>{{signedf .InnovationScore.Change}}</td></tr>
+</table>
+{{end}}
+</main>
+</body>
+</html>
+{{end}}`
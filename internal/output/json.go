@@ -0,0 +1,13 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON writes v as indented JSON
+func WriteJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github-profiler/internal/models"
+)
+
+// WriteMarkdown renders a profile as a Markdown report
+func WriteMarkdown(w io.Writer, profile *models.UserProfile) error {
+	var b strings.Builder
+	user := profile.User
+	stats := profile.Stats
+
+	fmt.Fprintf(&b, "# %s (%s)\n\n", orDash(user.Name), user.Login)
+	if user.Bio != "" {
+		fmt.Fprintf(&b, "> %s\n\n", user.Bio)
+	}
+
+	b.WriteString("## Overview\n\n")
+	b.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Company | %s |\n", orDash(user.Company))
+	fmt.Fprintf(&b, "| Location | %s |\n", orDash(user.Location))
+	fmt.Fprintf(&b, "| Website | %s |\n", orDash(user.Blog))
+	fmt.Fprintf(&b, "| Joined | %s |\n", user.CreatedAt.Format("January 2006"))
+	fmt.Fprintf(&b, "| Public Repos | %d |\n", user.PublicRepos)
+	fmt.Fprintf(&b, "| Followers | %d |\n", user.Followers)
+	fmt.Fprintf(&b, "| Following | %d |\n", user.Following)
+	fmt.Fprintf(&b, "| Total Stars | %d |\n", stats.TotalStars)
+	fmt.Fprintf(&b, "| Total Forks | %d |\n", stats.TotalForks)
+	fmt.Fprintf(&b, "| Repository Size | %.1f MB |\n", float64(stats.TotalSize)/1024)
+	fmt.Fprintf(&b, "| Avg Stars/Repo | %.1f |\n\n", stats.AvgStarsPerRepo)
+
+	b.WriteString("## Top Repositories\n\n")
+	b.WriteString("| Repository | Language | Stars | Forks | Updated |\n|---|---|---:|---:|---|\n")
+	for _, repo := range topRepositories(profile.Repositories, 10) {
+		fmt.Fprintf(&b, "| %s | %s | %d | %d | %s |\n",
+			escapeCell(repo.Name),
+			orDash(repo.Language),
+			repo.StargazersCount,
+			repo.ForksCount,
+			repo.UpdatedAt.Format("Jan 2006"))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Languages\n\n")
+	b.WriteString("| Language | Share | Repos |\n|---|---:|---:|\n")
+	for _, lang := range sortedLanguages(profile.Languages) {
+		fmt.Fprintf(&b, "| %s | %.1f%% | %d |\n", escapeCell(lang.Name), lang.Percentage, lang.RepoCount)
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Activity\n\n")
+	fmt.Fprintf(&b, "Contribution Score: %.1f\n\n", profile.Activity.ContributionScore)
+	for _, entry := range stats.CreationTimeline {
+		fmt.Fprintf(&b, "- %d: %d repositories\n", entry.Year, entry.Count)
+	}
+	b.WriteString("\n")
+
+	ranking := profile.Ranking
+	limits := ranking.MaxScores
+	b.WriteString("## Ranking\n\n")
+	fmt.Fprintf(&b, "**%s** - %s\n\n", ranking.Badge, ranking.OverallRank)
+	b.WriteString("| Component | Score |\n|---|---:|\n")
+	fmt.Fprintf(&b, "| Total | %.1f / %.0f |\n", ranking.TotalScore, limits.Total)
+	fmt.Fprintf(&b, "| Social | %.1f / %.0f |\n", ranking.SocialScore, limits.Social)
+	fmt.Fprintf(&b, "| Code | %.1f / %.0f |\n", ranking.CodeScore, limits.Code)
+	fmt.Fprintf(&b, "| Activity | %.1f / %.0f |\n", ranking.ActivityScore, limits.Activity)
+	fmt.Fprintf(&b, "| Innovation | %.1f / %.0f |\n", ranking.InnovationScore, limits.Innovation)
+	if ranking.Percentile != nil {
+		fmt.Fprintf(&b, "\nPercentile: %.1f (reference %s)\n", *ranking.Percentile, ranking.PercentileSource)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return escapeCell(s)
+}
+
+// escapeCell keeps pipes and newlines from breaking Markdown tables
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,401 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github-profiler/internal/charts"
+	"github-profiler/internal/models"
+)
+
+// cardLanguages is the number of languages shown on the stats card
+const cardLanguages = 5
+
+// maxCardLanguages bounds the languages listed on the languages card
+const maxCardLanguages = 10
+
+// languageBarWidth is the width of the stacked language bar on the card
+const languageBarWidth = 445.0
+
+var cardTemplate = template.Must(template.New("card").Parse(cardSVG))
+
+var languagesTemplate = template.Must(template.New("languages").Parse(languagesSVG))
+
+var badgeTemplate = template.Must(template.New("badge").Parse(badgeSVG))
+
+// CardTheme colours the SVG cards and badges
+type CardTheme struct {
+	Background string
+	Border     string
+	Title      string
+	Text       string
+	Muted      string
+	Accent     string
+	OnAccent   string
+	Track      string
+}
+
+// CardThemes are the themes the SVG cards can be rendered with, by name
+var CardThemes = map[string]CardTheme{
+	"dark": {
+		Background: "#0d1117", Border: "#30363d", Title: "#5fd7d7", Text: "#c9d1d9",
+		Muted: "#8b949e", Accent: "#5fd7d7", OnAccent: "#0d1117", Track: "#21262d",
+	},
+	"light": {
+		Background: "#ffffff", Border: "#d0d7de", Title: "#0969da", Text: "#1f2328",
+		Muted: "#656d76", Accent: "#0969da", OnAccent: "#ffffff", Track: "#eaeef2",
+	},
+	"high-contrast": {
+		Background: "#000000", Border: "#ffffff", Title: "#ffff00", Text: "#ffffff",
+		Muted: "#ffffff", Accent: "#00ffff", OnAccent: "#000000", Track: "#333333",
+	},
+}
+
+// CardThemeNames returns the card theme names in a stable order
+func CardThemeNames() []string {
+	names := make([]string, 0, len(CardThemes))
+	for name := range CardThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CardOptions customise the SVG cards
+type CardOptions struct {
+	// Theme names one of CardThemes; empty means dark
+	Theme string
+
+	// Hide lists parts to leave out. On the stats card these are the stats
+	// (stars, forks, followers, repos), the score and the languages; on the
+	// languages card they are language names. Matching ignores case.
+	Hide []string
+
+	// Languages is the number of languages on the languages card; zero means five
+	Languages int
+}
+
+// theme resolves the named theme
+func (o CardOptions) theme() (CardTheme, error) {
+	if o.Theme == "" {
+		return CardThemes["dark"], nil
+	}
+	theme, ok := CardThemes[o.Theme]
+	if !ok {
+		return CardTheme{}, fmt.Errorf("unknown card theme %q (available: %s)", o.Theme, strings.Join(CardThemeNames(), ", "))
+	}
+	return theme, nil
+}
+
+// hidden reports whether name is listed in Hide
+func (o CardOptions) hidden(name string) bool {
+	for _, hide := range o.Hide {
+		if strings.EqualFold(strings.TrimSpace(hide), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// cardStat is a labelled number on the card
+type cardStat struct {
+	Label string
+	Value string
+	Y     int
+}
+
+// cardSegment is one language in the stacked bar and legend of the card
+type cardSegment struct {
+	Name       string
+	Percentage float64
+	X          float64
+	Width      float64
+	LegendX    int
+	LegendY    int
+	Color      string
+}
+
+// WriteSVGCard renders a compact stats card suitable for embedding in a README
+func WriteSVGCard(w io.Writer, profile *models.UserProfile) error {
+	return WriteStatsCard(w, profile, CardOptions{})
+}
+
+// WriteStatsCard renders the stats card with the given theme and hidden parts
+func WriteStatsCard(w io.Writer, profile *models.UserProfile, opts CardOptions) error {
+	theme, err := opts.theme()
+	if err != nil {
+		return err
+	}
+
+	name := profile.User.Name
+	if name == "" {
+		name = profile.User.Login
+	}
+
+	all := []struct {
+		key string
+		cardStat
+	}{
+		{"stars", cardStat{Label: "Total Stars", Value: fmt.Sprint(profile.Stats.TotalStars)}},
+		{"forks", cardStat{Label: "Total Forks", Value: fmt.Sprint(profile.Stats.TotalForks)}},
+		{"followers", cardStat{Label: "Followers", Value: fmt.Sprint(profile.User.Followers)}},
+		{"repos", cardStat{Label: "Public Repos", Value: fmt.Sprint(profile.User.PublicRepos)}},
+	}
+	var stats []cardStat
+	for _, stat := range all {
+		if opts.hidden(stat.key) {
+			continue
+		}
+		stat.Y = 70 + len(stats)*25
+		stats = append(stats, stat.cardStat)
+	}
+
+	var segments []cardSegment
+	if !opts.hidden("languages") {
+		x := 0.0
+		for i, lang := range sortedLanguages(profile.Languages) {
+			if i == cardLanguages {
+				break
+			}
+			width := lang.Percentage / 100 * languageBarWidth
+			segments = append(segments, cardSegment{
+				Name:       lang.Name,
+				Percentage: lang.Percentage,
+				X:          x,
+				Width:      width,
+				LegendX:    i * 90,
+				Color:      charts.LanguageColor(lang.Name),
+			})
+			x += width
+		}
+	}
+
+	// The languages take the bottom of the card; without them it is shorter
+	height := 195
+	if len(segments) == 0 {
+		height = 150
+	}
+
+	data := struct {
+		Title     string
+		Theme     CardTheme
+		Height    int
+		Frame     int
+		Stats     []cardStat
+		ShowScore bool
+		Badge     string
+		Score     string
+		Languages []cardSegment
+	}{
+		Title:     name + "'s GitHub Stats",
+		Theme:     theme,
+		Height:    height,
+		Frame:     height - 1,
+		Stats:     stats,
+		ShowScore: !opts.hidden("score"),
+		Badge:     profile.Ranking.Badge,
+		Score:     fmt.Sprintf("%.0f", profile.Ranking.TotalScore),
+		Languages: segments,
+	}
+
+	return cardTemplate.Execute(w, data)
+}
+
+// WriteLanguagesCard renders a card of the user's most used languages
+func WriteLanguagesCard(w io.Writer, profile *models.UserProfile, opts CardOptions) error {
+	theme, err := opts.theme()
+	if err != nil {
+		return err
+	}
+
+	count := opts.Languages
+	if count <= 0 {
+		count = cardLanguages
+	}
+	count = min(count, maxCardLanguages)
+
+	var langs []models.LanguageInfo
+	total := 0.0
+	for _, lang := range sortedLanguages(profile.Languages) {
+		if len(langs) == count {
+			break
+		}
+		if opts.hidden(lang.Name) {
+			continue
+		}
+		langs = append(langs, lang)
+		total += lang.Percentage
+	}
+
+	// Shares are of the languages shown, so the bar is always full
+	var segments []cardSegment
+	const barWidth = 300.0
+	x := 0.0
+	for i, lang := range langs {
+		share := 0.0
+		if total > 0 {
+			share = lang.Percentage / total * 100
+		}
+		width := share / 100 * barWidth
+		segments = append(segments, cardSegment{
+			Name:       lang.Name,
+			Percentage: share,
+			X:          x,
+			Width:      width,
+			LegendX:    (i % 2) * 160,
+			LegendY:    (i / 2) * 22,
+			Color:      charts.LanguageColor(lang.Name),
+		})
+		x += width
+	}
+
+	// The legend lists two languages per row below the bar
+	height := 90 + (len(segments)+1)/2*22
+
+	data := struct {
+		Title     string
+		Theme     CardTheme
+		Height    int
+		Frame     int
+		Languages []cardSegment
+	}{
+		Title:     "Most Used Languages",
+		Theme:     theme,
+		Height:    height,
+		Frame:     height - 1,
+		Languages: segments,
+	}
+
+	return languagesTemplate.Execute(w, data)
+}
+
+// WriteBadge renders the ranking badge tier as a shields-style badge. The
+// colour follows the share of the maximum score, so custom tiers work too.
+func WriteBadge(w io.Writer, profile *models.UserProfile, opts CardOptions) error {
+	theme, err := opts.theme()
+	if err != nil {
+		return err
+	}
+
+	ratio := 0.0
+	if limit := profile.Ranking.MaxScores.Total; limit > 0 {
+		ratio = profile.Ranking.TotalScore / limit
+	}
+
+	const label = "github rank"
+	value := profile.Ranking.Badge
+	labelWidth := badgeTextWidth(label)
+	valueWidth := badgeTextWidth(value)
+
+	data := struct {
+		Label      string
+		Value      string
+		Theme      CardTheme
+		Color      string
+		LabelWidth int
+		ValueWidth int
+		Width      int
+		LabelX     int
+		ValueX     int
+	}{
+		Label:      label,
+		Value:      value,
+		Theme:      theme,
+		Color:      badgeColor(ratio),
+		LabelWidth: labelWidth,
+		ValueWidth: valueWidth,
+		Width:      labelWidth + valueWidth,
+		LabelX:     labelWidth / 2,
+		ValueX:     labelWidth + valueWidth/2,
+	}
+
+	return badgeTemplate.Execute(w, data)
+}
+
+// badgeTextWidth estimates the rendered width of badge text at 11px, plus padding
+func badgeTextWidth(text string) int {
+	return utf8.RuneCountInString(text)*7 + 12
+}
+
+// badgeColor picks the shields.io colour for a share of the maximum score
+func badgeColor(ratio float64) string {
+	switch {
+	case ratio >= 0.9:
+		return "#4c1"
+	case ratio >= 0.8:
+		return "#97ca00"
+	case ratio >= 0.7:
+		return "#a4a61d"
+	case ratio >= 0.6:
+		return "#dfb317"
+	case ratio >= 0.5:
+		return "#fe7d37"
+	default:
+		return "#e05d44"
+	}
+}
+
+const cardSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="495" height="{{.Height}}" viewBox="0 0 495 {{.Height}}" role="img" aria-label="{{.Title}}">
+<style>
+.title { font: 600 18px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Title}}; }
+.stat { font: 400 14px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Text}}; }
+.value { font: 700 14px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Text}}; }
+.badge { font: 700 12px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.OnAccent}}; }
+.score { font: 700 24px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Accent}}; }
+.lang { font: 400 11px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Muted}}; }
+</style>
+<rect x="0.5" y="0.5" rx="6" width="494" height="{{.Frame}}" fill="{{.Theme.Background}}" stroke="{{.Theme.Border}}"/>
+<text x="25" y="35" class="title">{{.Title}}</text>
+{{range .Stats}}<text x="25" y="{{.Y}}" class="stat">{{.Label}}:</text><text x="160" y="{{.Y}}" class="value">{{.Value}}</text>
+{{end}}{{if .ShowScore}}<circle cx="400" cy="80" r="40" fill="none" stroke="{{.Theme.Accent}}" stroke-width="5"/>
+<text x="400" y="88" text-anchor="middle" class="score">{{.Score}}</text>
+<rect x="345" y="128" rx="3" width="110" height="18" fill="{{.Theme.Accent}}"/>
+<text x="400" y="141" text-anchor="middle" class="badge">{{.Badge}}</text>
+{{end}}{{if .Languages}}<g transform="translate(25, 160)">
+<rect width="445" height="8" rx="4" fill="{{.Theme.Track}}"/>
+{{range .Languages}}<rect x="{{printf "%.1f" .X}}" width="{{printf "%.1f" .Width}}" height="8" fill="{{.Color}}"/>
+{{end}}</g>
+<g transform="translate(25, 185)">
+{{range .Languages}}<text x="{{.LegendX}}" class="lang"><tspan fill="{{.Color}}">&#9679;</tspan> {{.Name}} {{printf "%.1f" .Percentage}}%</text>
+{{end}}</g>
+{{end}}</svg>
+`
+
+const languagesSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="350" height="{{.Height}}" viewBox="0 0 350 {{.Height}}" role="img" aria-label="{{.Title}}">
+<style>
+.title { font: 600 18px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Title}}; }
+.lang { font: 400 12px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Text}}; }
+.empty { font: 400 12px "Segoe UI", Ubuntu, Sans-Serif; fill: {{.Theme.Muted}}; }
+</style>
+<rect x="0.5" y="0.5" rx="6" width="349" height="{{.Frame}}" fill="{{.Theme.Background}}" stroke="{{.Theme.Border}}"/>
+<text x="25" y="35" class="title">{{.Title}}</text>
+{{if .Languages}}<g transform="translate(25, 52)">
+<rect width="300" height="8" rx="4" fill="{{.Theme.Track}}"/>
+{{range .Languages}}<rect x="{{printf "%.1f" .X}}" width="{{printf "%.1f" .Width}}" height="8" fill="{{.Color}}"/>
+{{end}}</g>
+<g transform="translate(25, 85)">
+{{range .Languages}}<text x="{{.LegendX}}" y="{{.LegendY}}" class="lang"><tspan fill="{{.Color}}">&#9679;</tspan> {{.Name}} {{printf "%.1f" .Percentage}}%</text>
+{{end}}</g>
+{{else}}<text x="25" y="70" class="empty">No language data available</text>
+{{end}}</svg>
+`
+
+const badgeSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Value}}">
+<title>{{.Label}}: {{.Value}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)">
+<rect width="{{.LabelWidth}}" height="20" fill="{{.Theme.Background}}"/>
+<rect x="{{.LabelWidth}}" width="{{.ValueWidth}}" height="20" fill="{{.Color}}"/>
+<rect width="{{.Width}}" height="20" fill="url(#s)"/>
+</g>
+<g text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="14" fill="{{.Theme.Text}}">{{.Label}}</text>
+<text x="{{.ValueX}}" y="14" fill="#fff">{{.Value}}</text>
+</g>
+</svg>
+`
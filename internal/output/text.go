@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github-profiler/internal/charts"
+	"github-profiler/internal/history"
+)
+
+// WriteDiffText renders a snapshot diff as plain text
+func WriteDiffText(w io.Writer, diff history.Diff) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Changes for %s\n", diff.Login)
+	fmt.Fprintf(&b, "%s -> %s (%d snapshots)\n\n",
+		diff.From.Format("Jan 2, 2006"),
+		diff.To.Format("Jan 2, 2006"),
+		diff.Snapshots)
+
+	b.WriteString("Trends:\n")
+	for _, series := range diff.Trends {
+		fmt.Fprintf(&b, "   %-10s %s  %.1f (%+.1f)\n", series.Name, charts.Sparkline(series.Values), series.Latest(), series.Change())
+	}
+
+	b.WriteString("\nMetrics:\n")
+	writeIntDelta(&b, "Stars", diff.Stars)
+	writeIntDelta(&b, "Forks", diff.Forks)
+	writeIntDelta(&b, "Followers", diff.Followers)
+	writeIntDelta(&b, "Public Repos", diff.PublicRepos)
+
+	b.WriteString("\nRepositories:\n")
+	fmt.Fprintf(&b, "   New:      %s\n", joinOrNone(diff.NewRepos))
+	fmt.Fprintf(&b, "   Archived: %s\n", joinOrNone(diff.ArchivedRepos))
+
+	b.WriteString("\nLanguage Share:\n")
+	if len(diff.LanguageShift) == 0 {
+		b.WriteString("   No significant change\n")
+	}
+	for _, shift := range diff.LanguageShift {
+		fmt.Fprintf(&b, "   %-14s %5.1f%% -> %5.1f%% (%+.1f)\n",
+			shift.Name, shift.Percentage.From, shift.Percentage.To, shift.Percentage.Change)
+	}
+
+	ranking := diff.Ranking
+	fmt.Fprintf(&b, "\nRanking: %s -> %s\n", ranking.FromBadge, ranking.ToBadge)
+	writeFloatDelta(&b, "Total Score", ranking.TotalScore)
+	writeFloatDelta(&b, "Social Score", ranking.SocialScore)
+	writeFloatDelta(&b, "Code Score", ranking.CodeScore)
+	writeFloatDelta(&b, "Activity Score", ranking.ActivityScore)
+	writeFloatDelta(&b, "Innovation Score", ranking.InnovationScore)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeIntDelta(b *strings.Builder, name string, d history.IntDelta) {
+	fmt.Fprintf(b, "   %-17s %6d -> %6d (%+d)\n", name+":", d.From, d.To, d.Change)
+}
+
+func writeFloatDelta(b *strings.Builder, name string, d history.FloatDelta) {
+	fmt.Fprintf(b, "   %-17s %6.1f -> %6.1f (%+.1f)\n", name+":", d.From, d.To, d.Change)
+}
+
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,189 @@
+package scoring
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"sort"
+
+	"github-profiler/internal/models"
+)
+
+// bundled holds the reference distributions shipped with the binary, built
+// with 'reference build' (see reference/README.md)
+//
+//go:embed reference
+var bundled embed.FS
+
+// referencePercentiles are the quantiles recorded when building a distribution
+var referencePercentiles = []float64{0, 1, 5, 10, 20, 30, 40, 50, 60, 70, 75, 80, 85, 90, 95, 97, 99, 99.9, 100}
+
+// Distribution is a versioned reference distribution of total scores.
+// ScoringFingerprint identifies the rules the scores were computed with.
+type Distribution struct {
+	Version            string     `json:"version"`
+	Source             string     `json:"source"`
+	ScoringModel       string     `json:"scoring_model"`
+	ScoringFingerprint string     `json:"scoring_fingerprint"`
+	SampleSize         int        `json:"sample_size"`
+	Quantiles          []Quantile `json:"quantiles"`
+}
+
+// Quantile is the total score reached at a given percentile
+type Quantile struct {
+	Percentile float64 `json:"p"`
+	Score      float64 `json:"score"`
+}
+
+// BundledDistribution returns the bundled reference distribution built with
+// model's rules, or nil when none was. When several match, the one whose file
+// name sorts last wins.
+func BundledDistribution(model *Model) *Distribution {
+	names, err := fs.Glob(bundled, "reference/*.json")
+	if err != nil {
+		return nil
+	}
+	sort.Strings(names)
+
+	fingerprint := model.Fingerprint()
+	var match *Distribution
+	for _, name := range names {
+		data, _ := bundled.ReadFile(name)
+		dist, err := ParseDistribution(data)
+		if err != nil {
+			panic(fmt.Sprintf("invalid bundled reference distribution %s: %v", path.Base(name), err))
+		}
+		if dist.ScoringFingerprint == fingerprint {
+			match = dist
+		}
+	}
+	return match
+}
+
+// LoadDistribution reads a reference distribution from a JSON file
+func LoadDistribution(path string) (*Distribution, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference distribution: %w", err)
+	}
+
+	dist, err := ParseDistribution(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference distribution %s: %w", path, err)
+	}
+	return dist, nil
+}
+
+// ParseDistribution decodes and validates a reference distribution
+func ParseDistribution(data []byte) (*Distribution, error) {
+	var dist Distribution
+	if err := json.Unmarshal(data, &dist); err != nil {
+		return nil, err
+	}
+
+	if dist.Version == "" {
+		return nil, fmt.Errorf("version is required")
+	}
+	if dist.ScoringFingerprint == "" {
+		return nil, fmt.Errorf("scoring_fingerprint is required; rebuild the distribution with 'reference build'")
+	}
+	if len(dist.Quantiles) < 2 {
+		return nil, fmt.Errorf("at least two quantiles are required")
+	}
+	for _, q := range dist.Quantiles {
+		if q.Percentile < 0 || q.Percentile > 100 {
+			return nil, fmt.Errorf("quantile p%g is outside 0..100", q.Percentile)
+		}
+	}
+
+	sort.Slice(dist.Quantiles, func(i, j int) bool {
+		return dist.Quantiles[i].Percentile < dist.Quantiles[j].Percentile
+	})
+	for i := 1; i < len(dist.Quantiles); i++ {
+		if dist.Quantiles[i].Score < dist.Quantiles[i-1].Score {
+			return nil, fmt.Errorf("quantile scores must not decrease (p%g)", dist.Quantiles[i].Percentile)
+		}
+	}
+
+	return &dist, nil
+}
+
+// BuildDistribution computes a reference distribution from a sample of total
+// scores computed with model
+func BuildDistribution(version, source string, model *Model, scores []float64) (*Distribution, error) {
+	if len(scores) < 2 {
+		return nil, fmt.Errorf("at least two scores are needed, found %d", len(scores))
+	}
+
+	sorted := append([]float64(nil), scores...)
+	sort.Float64s(sorted)
+
+	dist := &Distribution{
+		Version:            version,
+		Source:             source,
+		ScoringModel:       model.Name,
+		ScoringFingerprint: model.Fingerprint(),
+		SampleSize:         len(sorted),
+	}
+	for _, p := range referencePercentiles {
+		dist.Quantiles = append(dist.Quantiles, Quantile{
+			Percentile: p,
+			Score:      math.Round(quantile(sorted, p)*100) / 100,
+		})
+	}
+
+	return dist, nil
+}
+
+// Percentile returns the share of the reference population scoring at or below score
+func (d *Distribution) Percentile(score float64) float64 {
+	q := d.Quantiles
+	if score < q[0].Score {
+		return 0
+	}
+	if score >= q[len(q)-1].Score {
+		return 100
+	}
+
+	// Find the last quantile at or below the score; ties resolve to the
+	// highest percentile sharing that score
+	i := sort.Search(len(q), func(i int) bool { return q[i].Score > score }) - 1
+	lower, upper := q[i], q[i+1]
+	if upper.Score == lower.Score {
+		return lower.Percentile
+	}
+
+	fraction := (score - lower.Score) / (upper.Score - lower.Score)
+	return lower.Percentile + fraction*(upper.Percentile-lower.Percentile)
+}
+
+// Apply sets the percentile of a ranking computed with model. Rankings from
+// other rules, including edited copies that kept the model's name, are left
+// without a percentile, since comparing them against this population would be
+// meaningless.
+func (d *Distribution) Apply(ranking *models.RankingInfo, model *Model) {
+	if d == nil || d.ScoringFingerprint != model.Fingerprint() {
+		ranking.Percentile = nil
+		ranking.PercentileSource = ""
+		return
+	}
+
+	percentile := d.Percentile(ranking.TotalScore)
+	ranking.Percentile = &percentile
+	ranking.PercentileSource = d.Version
+}
+
+// quantile returns the p-th percentile of sorted values using linear interpolation
+func quantile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
@@ -0,0 +1,129 @@
+package scoring
+
+import (
+	"math"
+	"time"
+
+	"github-profiler/internal/models"
+)
+
+// Inputs are the profile facts that rules can refer to
+type Inputs struct {
+	User      models.User
+	Stats     models.ProfileStats
+	Activity  models.ActivityStats
+	Languages models.LanguageStats
+}
+
+// InputsFromProfile collects scoring inputs from an assembled profile
+func InputsFromProfile(profile *models.UserProfile) Inputs {
+	return Inputs{
+		User:      profile.User,
+		Stats:     profile.Stats,
+		Activity:  profile.Activity,
+		Languages: profile.Languages,
+	}
+}
+
+// metrics maps the metric names usable in rules files to their values
+var metrics = map[string]func(Inputs) float64{
+	"followers": func(in Inputs) float64 {
+		return float64(in.User.Followers)
+	},
+	"following": func(in Inputs) float64 {
+		return float64(in.User.Following)
+	},
+	"public_repos": func(in Inputs) float64 {
+		return float64(in.Stats.RepoTypes["public"])
+	},
+	"private_repos": func(in Inputs) float64 {
+		return float64(in.Stats.RepoTypes["private"])
+	},
+	"fork_repos": func(in Inputs) float64 {
+		return float64(in.Stats.RepoTypes["forks"])
+	},
+	"total_stars": func(in Inputs) float64 {
+		return float64(in.Stats.TotalStars)
+	},
+	"total_forks": func(in Inputs) float64 {
+		return float64(in.Stats.TotalForks)
+	},
+	"total_size_kb": func(in Inputs) float64 {
+		return float64(in.Stats.TotalSize)
+	},
+	"avg_stars_per_repo": func(in Inputs) float64 {
+		return in.Stats.AvgStarsPerRepo
+	},
+	"contribution_score": func(in Inputs) float64 {
+		return in.Activity.ContributionScore
+	},
+	"language_count": func(in Inputs) float64 {
+		return float64(len(in.Languages.Languages))
+	},
+	"account_age_years": func(in Inputs) float64 {
+		createdAt := in.User.CreatedAt
+		if createdAt.IsZero() {
+			return 0
+		}
+		return time.Since(createdAt).Hours() / 24 / 365.25
+	},
+}
+
+// Evaluate scores the inputs with the model
+func (m *Model) Evaluate(in Inputs) models.RankingInfo {
+	c := m.Components
+	social := c.Social.evaluate(in)
+	code := c.Code.evaluate(in)
+	activity := c.Activity.evaluate(in)
+	innovation := c.Innovation.evaluate(in)
+
+	totalScore := social + code + activity + innovation
+	maxScore := m.MaxScore()
+
+	tier := m.Tier(totalScore)
+
+	return models.RankingInfo{
+		OverallRank:     tier.Name,
+		Badge:           tier.Badge,
+		TotalScore:      totalScore,
+		SocialScore:     social,
+		CodeScore:       code,
+		ActivityScore:   activity,
+		InnovationScore: innovation,
+		ScoringModel:    m.Name,
+		MaxScores: models.ScoreLimits{
+			Total:      maxScore,
+			Social:     c.Social.limit(),
+			Code:       c.Code.limit(),
+			Activity:   c.Activity.limit(),
+			Innovation: c.Innovation.limit(),
+		},
+	}
+}
+
+// evaluate sums the rule points, caps them at Max and applies the weight
+func (c Component) evaluate(in Inputs) float64 {
+	points := 0.0
+	for _, rule := range c.Rules {
+		points += rule.evaluate(metrics[rule.Metric](in))
+	}
+	return math.Min(points, c.Max) * c.weight()
+}
+
+// evaluate returns the points the rule awards for a metric value
+func (r Rule) evaluate(value float64) float64 {
+	if len(r.Thresholds) > 0 {
+		for _, threshold := range r.Thresholds {
+			if value >= threshold.Min {
+				return threshold.Points
+			}
+		}
+		return r.Default
+	}
+
+	points := value * r.Per
+	if r.Cap != nil && points > *r.Cap {
+		points = *r.Cap
+	}
+	return points
+}
@@ -0,0 +1,203 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github-profiler/internal/models"
+)
+
+// maxHints bounds the number of improvement suggestions
+const maxHints = 5
+
+// Explain scores the inputs like Evaluate and also records every rule that
+// fired, every cap that applied and what would raise the score
+func (m *Model) Explain(in Inputs) models.RankingInfo {
+	ranking := m.Evaluate(in)
+
+	explanation := &models.RankingExplanation{}
+	for _, named := range m.namedComponents() {
+		rules, hints := named.component.explain(named.name, in)
+		explanation.Rules = append(explanation.Rules, rules...)
+		explanation.Hints = append(explanation.Hints, hints...)
+	}
+
+	sort.SliceStable(explanation.Hints, func(i, j int) bool {
+		return explanation.Hints[i].Gain > explanation.Hints[j].Gain
+	})
+	if len(explanation.Hints) > maxHints {
+		explanation.Hints = explanation.Hints[:maxHints]
+	}
+
+	// The distance to the next tier always leads the hints
+	if hint, ok := m.tierHint(ranking.TotalScore); ok {
+		explanation.Hints = append([]models.ScoreHint{hint}, explanation.Hints...)
+	}
+
+	ranking.Explanation = explanation
+	return ranking
+}
+
+// namedComponent pairs a component with its name for reporting
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+func (m *Model) namedComponents() []namedComponent {
+	return []namedComponent{
+		{"social", m.Components.Social},
+		{"code", m.Components.Code},
+		{"activity", m.Components.Activity},
+		{"innovation", m.Components.Innovation},
+	}
+}
+
+// explain records the contribution of every rule in the component and suggests improvements
+func (c Component) explain(name string, in Inputs) ([]models.RuleContribution, []models.ScoreHint) {
+	var rules []models.RuleContribution
+	raw := 0.0
+
+	for _, rule := range c.Rules {
+		value := metrics[rule.Metric](in)
+		points := rule.evaluate(value)
+		raw += points
+
+		rules = append(rules, models.RuleContribution{
+			Component:   name,
+			Metric:      rule.Metric,
+			Input:       value,
+			Points:      points,
+			Capped:      rule.capped(value),
+			Description: rule.describe(name, value, points),
+		})
+	}
+
+	capped := math.Min(raw, c.Max)
+	if raw > c.Max {
+		rules = append(rules, models.RuleContribution{
+			Component:   name,
+			Input:       raw,
+			Points:      capped,
+			Capped:      true,
+			Description: fmt.Sprintf("%s score %s capped at %s", name, num(raw), num(c.Max)),
+		})
+	}
+	if c.weight() != 1 {
+		rules = append(rules, models.RuleContribution{
+			Component:   name,
+			Input:       capped,
+			Points:      capped * c.weight(),
+			Description: fmt.Sprintf("%s score %s × weight %s = %s", name, num(capped), num(c.weight()), num(capped*c.weight())),
+		})
+	}
+
+	// Improvements are bounded by the room left below the component cap
+	headroom := (c.Max - capped) * c.weight()
+	if headroom <= 0 {
+		return rules, nil
+	}
+
+	var hints []models.ScoreHint
+	for _, rule := range c.Rules {
+		if hint, ok := rule.hint(name, metrics[rule.Metric](in), c.weight(), headroom); ok {
+			hints = append(hints, hint)
+		}
+	}
+	return rules, hints
+}
+
+// capped reports whether a linear rule hit its cap
+func (r Rule) capped(value float64) bool {
+	return len(r.Thresholds) == 0 && r.Cap != nil && value*r.Per > *r.Cap
+}
+
+// describe renders a human readable account of a fired rule
+func (r Rule) describe(component string, value, points float64) string {
+	if len(r.Thresholds) > 0 {
+		for _, threshold := range r.Thresholds {
+			if value >= threshold.Min {
+				return fmt.Sprintf("%s %s >= %s → +%s %s", r.Metric, num(value), num(threshold.Min), num(points), component)
+			}
+		}
+		lowest := r.Thresholds[len(r.Thresholds)-1]
+		return fmt.Sprintf("%s %s < %s → +%s %s (default)", r.Metric, num(value), num(lowest.Min), num(points), component)
+	}
+
+	if r.capped(value) {
+		return fmt.Sprintf("%s %s × %s = %s, capped at %s → +%s %s",
+			r.Metric, num(value), num(r.Per), num(value*r.Per), num(*r.Cap), num(points), component)
+	}
+	return fmt.Sprintf("%s %s × %s → +%s %s", r.Metric, num(value), num(r.Per), num(points), component)
+}
+
+// hint suggests the next step that would earn more points from this rule
+func (r Rule) hint(component string, value, weight, headroom float64) (models.ScoreHint, bool) {
+	current := r.evaluate(value)
+
+	if len(r.Thresholds) > 0 {
+		// Thresholds are ordered highest first, so walk from the lowest up
+		for i := len(r.Thresholds) - 1; i >= 0; i-- {
+			threshold := r.Thresholds[i]
+			if threshold.Min <= value || threshold.Points <= current {
+				continue
+			}
+			gain := math.Min((threshold.Points-current)*weight, headroom)
+			return models.ScoreHint{
+				Component: component,
+				Metric:    r.Metric,
+				Gain:      gain,
+				Message: fmt.Sprintf("reach %s %s (%s more) for +%s %s",
+					num(threshold.Min), r.Metric, num(threshold.Min-value), num(gain), component),
+			}, true
+		}
+		return models.ScoreHint{}, false
+	}
+
+	if r.Per <= 0 || r.capped(value) {
+		return models.ScoreHint{}, false
+	}
+
+	// Suggest the step that fills the remaining room of the rule or component
+	room := headroom / weight
+	if r.Cap != nil {
+		room = math.Min(room, *r.Cap-current)
+	}
+	if room <= 0 {
+		return models.ScoreHint{}, false
+	}
+	needed := math.Ceil(room / r.Per)
+	gain := math.Min(needed*r.Per, room) * weight
+
+	return models.ScoreHint{
+		Component: component,
+		Metric:    r.Metric,
+		Gain:      gain,
+		Message: fmt.Sprintf("%s more %s for up to +%s %s (%s per unit)",
+			num(needed), r.Metric, num(gain), component, num(r.Per*weight)),
+	}, true
+}
+
+// tierHint reports how far the score is from the next tier
+func (m *Model) tierHint(score float64) (models.ScoreHint, bool) {
+	current := m.Tier(score)
+	for i := len(m.Tiers) - 1; i >= 0; i-- {
+		tier := m.Tiers[i]
+		if tier.Min > current.Min {
+			gap := tier.Min - score
+			return models.ScoreHint{
+				Component: "total",
+				Gain:      gap,
+				Message:   fmt.Sprintf("%s more points to reach %s (%s)", num(gap), tier.Name, tier.Badge),
+			}, true
+		}
+	}
+	return models.ScoreHint{}, false
+}
+
+// num formats a number without trailing zeros
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,214 @@
+package scoring
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed default.yaml
+var defaultRules []byte
+
+// Model is a declarative scoring model loaded from a rules file
+type Model struct {
+	Version    int        `yaml:"version"`
+	Name       string     `yaml:"name"`
+	Components Components `yaml:"components"`
+	Tiers      []Tier     `yaml:"tiers"`
+}
+
+// Components holds the four ranking components
+type Components struct {
+	Social     Component `yaml:"social"`
+	Code       Component `yaml:"code"`
+	Activity   Component `yaml:"activity"`
+	Innovation Component `yaml:"innovation"`
+}
+
+// Component is a group of rules whose points are capped and weighted together.
+// Weight defaults to 1 when absent; an explicit 0 switches the component off.
+type Component struct {
+	Max    float64  `yaml:"max"`
+	Weight *float64 `yaml:"weight"`
+	Rules  []Rule   `yaml:"rules"`
+}
+
+// Rule awards points for a single metric, either linearly or by threshold
+type Rule struct {
+	Metric     string      `yaml:"metric"`
+	Per        float64     `yaml:"per"`
+	Cap        *float64    `yaml:"cap"`
+	Thresholds []Threshold `yaml:"thresholds"`
+	Default    float64     `yaml:"default"`
+}
+
+// Threshold awards points once a metric reaches Min
+type Threshold struct {
+	Min    float64 `yaml:"min"`
+	Points float64 `yaml:"points"`
+}
+
+// Tier is a developer level reached at a minimum total score
+type Tier struct {
+	Name        string  `yaml:"name"`
+	Badge       string  `yaml:"badge"`
+	Min         float64 `yaml:"min"`
+	Description string  `yaml:"description"`
+}
+
+// Default returns the built-in scoring model
+func Default() *Model {
+	model, err := Parse(defaultRules)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in scoring model: %v", err))
+	}
+	return model
+}
+
+// Load reads a scoring model from a YAML rules file
+func Load(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring rules: %w", err)
+	}
+
+	model, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scoring rules %s: %w", path, err)
+	}
+	return model, nil
+}
+
+// Parse decodes and validates a scoring model
+func Parse(data []byte) (*Model, error) {
+	var model Model
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+
+	if err := model.normalize(); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// DefaultRules returns the YAML source of the built-in model as a starting point for custom rules
+func DefaultRules() []byte {
+	return defaultRules
+}
+
+// MaxScore returns the highest total score the model can award
+func (m *Model) MaxScore() float64 {
+	total := 0.0
+	for _, component := range m.Components.all() {
+		total += component.limit()
+	}
+	return total
+}
+
+// Fingerprint identifies the normalized component rules, which alone decide
+// the total score. Names, comments and tiers do not change it.
+func (m *Model) Fingerprint() string {
+	h := sha256.New()
+	for i, c := range m.Components.all() {
+		fmt.Fprintf(h, "component %d max=%g weight=%g\n", i, c.Max, c.weight())
+		for _, rule := range c.Rules {
+			fmt.Fprintf(h, "rule %s per=%g default=%g", rule.Metric, rule.Per, rule.Default)
+			if rule.Cap != nil {
+				fmt.Fprintf(h, " cap=%g", *rule.Cap)
+			}
+			for _, threshold := range rule.Thresholds {
+				fmt.Fprintf(h, " %g:%g", threshold.Min, threshold.Points)
+			}
+			fmt.Fprintln(h)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// Tier returns the tier reached by score
+func (m *Model) Tier(score float64) Tier {
+	for _, tier := range m.Tiers {
+		if score >= tier.Min {
+			return tier
+		}
+	}
+	return m.Tiers[len(m.Tiers)-1]
+}
+
+// normalize validates the model, fills defaults and orders tiers from highest to lowest
+func (m *Model) normalize() error {
+	if m.Version != 1 {
+		return fmt.Errorf("unsupported scoring model version %d", m.Version)
+	}
+	if m.Name == "" {
+		m.Name = "custom"
+	}
+
+	components := map[string]*Component{
+		"social":     &m.Components.Social,
+		"code":       &m.Components.Code,
+		"activity":   &m.Components.Activity,
+		"innovation": &m.Components.Innovation,
+	}
+	for name, component := range components {
+		if component.Max < 0 {
+			return fmt.Errorf("component %s: max must not be negative", name)
+		}
+		// Points are capped at max, so rules without one could never score
+		if component.Max == 0 && len(component.Rules) > 0 {
+			return fmt.Errorf("component %s: max is required when the component has rules", name)
+		}
+		if component.Weight != nil && *component.Weight < 0 {
+			return fmt.Errorf("component %s: weight must not be negative", name)
+		}
+		for i, rule := range component.Rules {
+			if _, ok := metrics[rule.Metric]; !ok {
+				return fmt.Errorf("component %s rule %d: unknown metric %q", name, i+1, rule.Metric)
+			}
+			if rule.Per != 0 && len(rule.Thresholds) > 0 {
+				return fmt.Errorf("component %s rule %d: use either per or thresholds, not both", name, i+1)
+			}
+			sort.Slice(rule.Thresholds, func(a, b int) bool {
+				return rule.Thresholds[a].Min > rule.Thresholds[b].Min
+			})
+		}
+	}
+
+	if len(m.Tiers) == 0 {
+		return fmt.Errorf("at least one tier is required")
+	}
+	for i, tier := range m.Tiers {
+		if tier.Name == "" || tier.Badge == "" {
+			return fmt.Errorf("tier %d: name and badge are required", i+1)
+		}
+	}
+	sort.SliceStable(m.Tiers, func(i, j int) bool {
+		return m.Tiers[i].Min > m.Tiers[j].Min
+	})
+
+	return nil
+}
+
+// all returns the components in display order
+func (c Components) all() []Component {
+	return []Component{c.Social, c.Code, c.Activity, c.Innovation}
+}
+
+// limit is the highest weighted score the component can contribute
+func (c Component) limit() float64 {
+	return c.Max * c.weight()
+}
+
+// weight is the multiplier of the component's points, 1 unless set
+func (c Component) weight() float64 {
+	if c.Weight == nil {
+		return 1
+	}
+	return *c.Weight
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-profiler/internal/models"
+	"github-profiler/internal/output"
+)
+
+// maxCardCacheAge bounds how long clients may cache a card
+const maxCardCacheAge = 24 * time.Hour
+
+// CardHandler serves SVG cards for embedding in READMEs:
+//
+//	/badge/{login}.svg              ranking badge
+//	/card/{login}.svg               stats card with top languages
+//	/card/{login}/languages.svg     most used languages
+//
+// Every endpoint accepts theme (dark, light, high-contrast), hide (comma
+// separated) and cache_seconds. The languages card also accepts langs_count.
+func (s *Server) CardHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /badge/{file}", s.cardEndpoint(output.WriteBadge))
+	mux.HandleFunc("GET /card/{file}", s.cardEndpoint(output.WriteStatsCard))
+	mux.HandleFunc("GET /card/{login}/languages.svg", s.cardEndpoint(output.WriteLanguagesCard))
+	return mux
+}
+
+// cardEndpoint returns a handler rendering the profile named in the path with write
+func (s *Server) cardEndpoint(write func(io.Writer, *models.UserProfile, output.CardOptions) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		login := r.PathValue("login")
+		if login == "" {
+			file := r.PathValue("file")
+			if !strings.HasSuffix(file, ".svg") {
+				writeErrorCard(w, http.StatusNotFound, "cards are served as {login}.svg")
+				return
+			}
+			login = strings.TrimSuffix(file, ".svg")
+		}
+
+		opts, maxAge, err := s.cardOptions(r)
+		if err != nil {
+			writeErrorCard(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		profile, err := s.Profile(r.Context(), login)
+		if err != nil {
+			writeErrorCard(w, statusFor(err), err.Error())
+			return
+		}
+
+		// Render into a buffer so a template error never leaves half a card behind
+		var b bytes.Buffer
+		if err := write(&b, profile, opts); err != nil {
+			writeErrorCard(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+		w.Write(b.Bytes())
+	}
+}
+
+// cardOptions reads the card query parameters. cache_seconds may lengthen
+// client caching up to a day but never shortens it below the server cache
+// TTL, since a fresher card could not be rendered anyway.
+func (s *Server) cardOptions(r *http.Request) (output.CardOptions, time.Duration, error) {
+	query := r.URL.Query()
+
+	opts := output.CardOptions{Theme: query.Get("theme")}
+	if hide := query.Get("hide"); hide != "" {
+		opts.Hide = strings.Split(hide, ",")
+	}
+	if count := query.Get("langs_count"); count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 1 {
+			return opts, 0, fmt.Errorf("langs_count must be a positive number")
+		}
+		opts.Languages = n
+	}
+
+	maxAge := s.profiles.TTL()
+	if seconds := query.Get("cache_seconds"); seconds != "" {
+		n, err := strconv.Atoi(seconds)
+		if err != nil || n < 0 {
+			return opts, 0, fmt.Errorf("cache_seconds must be a non-negative number")
+		}
+		maxAge = max(time.Duration(n)*time.Second, s.profiles.TTL())
+	}
+	return opts, min(maxAge, maxCardCacheAge), nil
+}
+
+var errorCardTemplate = template.Must(template.New("error").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="495" height="60" viewBox="0 0 495 60" role="img" aria-label="Error">
+<rect x="0.5" y="0.5" rx="6" width="494" height="59" fill="#0d1117" stroke="#f85149"/>
+<text x="25" y="35" fill="#f85149" font-family="Segoe UI, Ubuntu, Sans-Serif" font-size="14">{{.}}</text>
+</svg>
+`))
+
+// writeErrorCard reports an error as an SVG so it shows up in place of the
+// card instead of as a broken image
+func writeErrorCard(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	errorCardTemplate.Execute(w, message)
+}
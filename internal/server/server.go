@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+
+	"github-profiler/internal/cache"
+	"github-profiler/internal/models"
+	"github-profiler/internal/services"
+)
+
+// DefaultCacheTTL is how long a fetched profile is served before it is fetched again
+const DefaultCacheTTL = 10 * time.Minute
+
+// maxCompared bounds the number of users in a single comparison
+const maxCompared = 10
+
+// loginPattern matches valid GitHub logins
+var loginPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+
+// errInvalidLogin is returned for logins GitHub would never accept
+var errInvalidLogin = errors.New("invalid login")
+
+// ProfileFetcher fetches a user profile, usually from the GitHub API
+type ProfileFetcher interface {
+	GetUserProfile(login string) (*models.UserProfile, error)
+}
+
+// OrgFetcher fetches an organization profile. Fetchers that implement it
+// also get the /api/v1/orgs/{login} endpoint.
+type OrgFetcher interface {
+	GetOrgProfile(login string) (*models.OrgProfile, error)
+}
+
+// Server answers profile requests over HTTP, sharing one cache between clients
+// and fetching each login at most once at a time
+type Server struct {
+	fetcher ProfileFetcher
+	mux     *http.ServeMux
+
+	profiles     *cache.Cache[*models.UserProfile]
+	profileCalls cache.Group[*models.UserProfile]
+	orgs         *cache.Cache[*models.OrgProfile]
+	orgCalls     cache.Group[*models.OrgProfile]
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithCacheTTL serves fetched profiles for ttl before fetching them again. A
+// zero ttl disables caching, while concurrent requests are still coalesced.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.profiles = cache.New[*models.UserProfile](ttl)
+		s.orgs = cache.New[*models.OrgProfile](ttl)
+	}
+}
+
+// New creates a server that fetches profiles with fetcher
+func New(fetcher ProfileFetcher, opts ...Option) *Server {
+	s := &Server{
+		fetcher:  fetcher,
+		mux:      http.NewServeMux(),
+		profiles: cache.New[*models.UserProfile](DefaultCacheTTL),
+		orgs:     cache.New[*models.OrgProfile](DefaultCacheTTL),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /api/v1/users/{login}", s.handleProfile)
+	s.mux.HandleFunc("GET /api/v1/users/{login}/ranking", s.handleRanking)
+	s.mux.HandleFunc("GET /api/v1/compare", s.handleCompare)
+	if _, ok := fetcher.(OrgFetcher); ok {
+		s.mux.HandleFunc("GET /api/v1/orgs/{login}", s.handleOrg)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Profile returns the profile for login from the cache, or fetches it. Only
+// one fetch per login runs at a time; concurrent callers share its result and
+// stop waiting for it when ctx is done.
+func (s *Server) Profile(ctx context.Context, login string) (*models.UserProfile, error) {
+	if !loginPattern.MatchString(login) {
+		return nil, fmt.Errorf("%w: %q", errInvalidLogin, login)
+	}
+
+	return cache.Fetch(ctx, s.profiles, &s.profileCalls, strings.ToLower(login), func() (*models.UserProfile, error) {
+		return s.fetcher.GetUserProfile(login)
+	})
+}
+
+// Org returns the organization profile for login, cached and coalesced like Profile
+func (s *Server) Org(ctx context.Context, login string) (*models.OrgProfile, error) {
+	orgs, ok := s.fetcher.(OrgFetcher)
+	if !ok {
+		return nil, errors.New("organizations are not supported")
+	}
+	if !loginPattern.MatchString(login) {
+		return nil, fmt.Errorf("%w: %q", errInvalidLogin, login)
+	}
+
+	return cache.Fetch(ctx, s.orgs, &s.orgCalls, strings.ToLower(login), func() (*models.OrgProfile, error) {
+		return orgs.GetOrgProfile(login)
+	})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := s.Profile(r.Context(), r.PathValue("login"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, profile)
+}
+
+func (s *Server) handleRanking(w http.ResponseWriter, r *http.Request) {
+	profile, err := s.Profile(r.Context(), r.PathValue("login"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, profile.Ranking)
+}
+
+func (s *Server) handleOrg(w http.ResponseWriter, r *http.Request) {
+	org, err := s.Org(r.Context(), r.PathValue("login"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, org)
+}
+
+func (s *Server) handleCompare(w http.ResponseWriter, r *http.Request) {
+	logins := r.URL.Query()["u"]
+	if len(logins) < 2 || len(logins) > maxCompared {
+		writeJSON(w, http.StatusBadRequest, errorBody{
+			Error: fmt.Sprintf("compare needs between 2 and %d users, e.g. ?u=alice&u=bob", maxCompared),
+		})
+		return
+	}
+
+	// Fetch every user at once; the slowest fetch bounds the response time
+	profiles := make([]*models.UserProfile, len(logins))
+	errs := make([]error, len(logins))
+	var wg sync.WaitGroup
+	for i, login := range logins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profiles[i], errs[i] = s.Profile(r.Context(), login)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, services.Compare(profiles))
+}
+
+// errorBody is the JSON body of every error response
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// writeError reports err with the status code that best describes it
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusFor(err), errorBody{Error: err.Error()})
+}
+
+// statusFor maps a fetch error to an HTTP status code
+func statusFor(err error) int {
+	var rateLimit *github.RateLimitError
+	var abuse *github.AbuseRateLimitError
+	var response *github.ErrorResponse
+
+	switch {
+	case errors.Is(err, errInvalidLogin):
+		return http.StatusBadRequest
+	case errors.As(err, &rateLimit), errors.As(err, &abuse):
+		return http.StatusTooManyRequests
+	case errors.As(err, &response) && response.Response != nil && response.Response.StatusCode == http.StatusNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(v)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// App identifies a GitHub App installation the service authenticates as
+type App struct {
+	ID             int64
+	InstallationID int64
+	PrivateKey     *rsa.PrivateKey
+}
+
+// defaultAPIURL is used for token exchanges when no base URL is set
+var defaultAPIURL = &url.URL{Scheme: "https", Host: "api.github.com", Path: "/"}
+
+// Installation tokens are valid for an hour; they are replaced a little early
+// so a request never goes out with a token about to expire
+const installationTokenEarlyExpiry = 5 * time.Minute
+
+// WithApp authenticates as a GitHub App installation instead of with a token.
+// Installation tokens are requested as needed and refreshed before they expire.
+func WithApp(app App) Option {
+	return func(s *GitHubService) {
+		if app.PrivateKey != nil {
+			s.app = &app
+		}
+	}
+}
+
+// ParsePrivateKey reads a GitHub App private key in PEM format, as downloaded
+// from the App settings (PKCS #1) or converted to PKCS #8
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not in PEM format")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// installationTokenSource exchanges App JWTs for installation tokens
+type installationTokenSource struct {
+	app     App
+	baseURL *url.URL
+	client  *http.Client
+}
+
+// newInstallationTokenSource returns a token source that reuses an
+// installation token until shortly before it expires
+func newInstallationTokenSource(app App, baseURL *url.URL, client *http.Client) oauth2.TokenSource {
+	if baseURL == nil {
+		baseURL = defaultAPIURL
+	}
+	src := &installationTokenSource{app: app, baseURL: baseURL, client: client}
+	return oauth2.ReuseTokenSourceWithExpiry(nil, src, installationTokenEarlyExpiry)
+}
+
+// Token implements oauth2.TokenSource
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := s.baseURL.JoinPath("app", "installations", strconv.FormatInt(s.app.InstallationID, 10), "access_tokens")
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to request installation token for app %d, installation %d: %s: %s",
+			s.app.ID, s.app.InstallationID, resp.Status, body)
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid installation token response: %w", err)
+	}
+	return &oauth2.Token{AccessToken: result.Token, TokenType: "Bearer", Expiry: result.ExpiresAt}, nil
+}
+
+// jwt signs the short-lived RS256 token that authenticates as the App itself.
+// It is backdated a minute to tolerate clock drift; GitHub accepts at most ten
+// minutes of validity.
+func (s *installationTokenSource) jwt() (string, error) {
+	now := time.Now()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(s.app.ID, 10),
+	})
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.app.PrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app JWT: %w", err)
+	}
+	return unsigned + "." + encoding.EncodeToString(signature), nil
+}
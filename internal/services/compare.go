@@ -0,0 +1,91 @@
+package services
+
+import (
+	"sort"
+
+	"github-profiler/internal/models"
+)
+
+// comparedLanguages is the number of top languages listed per compared user
+const comparedLanguages = 3
+
+// Compare summarises profiles side by side, in the order given
+func Compare(profiles []*models.UserProfile) models.Comparison {
+	comparison := models.Comparison{
+		Users:   make([]models.ComparedUser, len(profiles)),
+		Leaders: make(map[string]string),
+	}
+
+	metrics := map[string]func(models.ComparedUser) float64{
+		"followers":    func(u models.ComparedUser) float64 { return float64(u.Followers) },
+		"public_repos": func(u models.ComparedUser) float64 { return float64(u.PublicRepos) },
+		"total_stars":  func(u models.ComparedUser) float64 { return float64(u.TotalStars) },
+		"total_forks":  func(u models.ComparedUser) float64 { return float64(u.TotalForks) },
+		"total_score":  func(u models.ComparedUser) float64 { return u.TotalScore },
+	}
+
+	languageUsers := make(map[string]int)
+	for i, profile := range profiles {
+		user := models.ComparedUser{
+			Login:            profile.User.Login,
+			Name:             profile.User.Name,
+			Followers:        profile.User.Followers,
+			PublicRepos:      profile.User.PublicRepos,
+			TotalStars:       profile.Stats.TotalStars,
+			TotalForks:       profile.Stats.TotalForks,
+			TopLanguages:     topLanguages(profile.Languages, comparedLanguages),
+			Badge:            profile.Ranking.Badge,
+			TotalScore:       profile.Ranking.TotalScore,
+			Percentile:       profile.Ranking.Percentile,
+			PercentileSource: profile.Ranking.PercentileSource,
+		}
+		comparison.Users[i] = user
+
+		for name := range profile.Languages.Languages {
+			languageUsers[name]++
+		}
+	}
+
+	// The first user listed wins ties
+	for metric, value := range metrics {
+		best := -1
+		for i, user := range comparison.Users {
+			if best < 0 || value(user) > value(comparison.Users[best]) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			comparison.Leaders[metric] = comparison.Users[best].Login
+		}
+	}
+
+	comparison.SharedLanguages = []string{}
+	for name, count := range languageUsers {
+		if count == len(profiles) {
+			comparison.SharedLanguages = append(comparison.SharedLanguages, name)
+		}
+	}
+	sort.Strings(comparison.SharedLanguages)
+
+	return comparison
+}
+
+// topLanguages returns the names of the n languages with the largest share
+func topLanguages(stats models.LanguageStats, n int) []string {
+	langs := make([]models.LanguageInfo, 0, len(stats.Languages))
+	for _, lang := range stats.Languages {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if langs[i].Percentage != langs[j].Percentage {
+			return langs[i].Percentage > langs[j].Percentage
+		}
+		return langs[i].Name < langs[j].Name
+	})
+
+	names := make([]string, 0, n)
+	for i := 0; i < len(langs) && i < n; i++ {
+		names = append(names, langs[i].Name)
+	}
+	return names
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"github.com/google/go-github/v73/github"
+
+	"github-profiler/internal/models"
+)
+
+// The functions below are the only place go-github types are turned into
+// models, so a go-github upgrade cannot change the output schema.
+
+func userFromGitHub(u *github.User) models.User {
+	return models.User{
+		Login:           u.GetLogin(),
+		ID:              u.GetID(),
+		Name:            u.GetName(),
+		Bio:             u.GetBio(),
+		Company:         u.GetCompany(),
+		Location:        u.GetLocation(),
+		Blog:            u.GetBlog(),
+		Email:           u.GetEmail(),
+		TwitterUsername: u.GetTwitterUsername(),
+		AvatarURL:       u.GetAvatarURL(),
+		HTMLURL:         u.GetHTMLURL(),
+		Type:            u.GetType(),
+		PublicRepos:     u.GetPublicRepos(),
+		PublicGists:     u.GetPublicGists(),
+		Followers:       u.GetFollowers(),
+		Following:       u.GetFollowing(),
+		CreatedAt:       u.GetCreatedAt().Time,
+		UpdatedAt:       u.GetUpdatedAt().Time,
+	}
+}
+
+func organizationFromGitHub(o *github.Organization) models.Organization {
+	return models.Organization{
+		Login:       o.GetLogin(),
+		ID:          o.GetID(),
+		Name:        o.GetName(),
+		Description: o.GetDescription(),
+		Company:     o.GetCompany(),
+		Location:    o.GetLocation(),
+		Blog:        o.GetBlog(),
+		Email:       o.GetEmail(),
+		AvatarURL:   o.GetAvatarURL(),
+		HTMLURL:     o.GetHTMLURL(),
+		PublicRepos: o.GetPublicRepos(),
+		Followers:   o.GetFollowers(),
+		CreatedAt:   o.GetCreatedAt().Time,
+	}
+}
+
+func repositoryFromGitHub(r *github.Repository) *models.Repository {
+	repo := &models.Repository{
+		ID:              r.GetID(),
+		Name:            r.GetName(),
+		FullName:        r.GetFullName(),
+		Owner:           models.Owner{Login: r.GetOwner().GetLogin()},
+		Description:     r.GetDescription(),
+		HTMLURL:         r.GetHTMLURL(),
+		Homepage:        r.GetHomepage(),
+		Language:        r.GetLanguage(),
+		Topics:          r.Topics,
+		DefaultBranch:   r.GetDefaultBranch(),
+		Fork:            r.GetFork(),
+		Private:         r.GetPrivate(),
+		Archived:        r.GetArchived(),
+		StargazersCount: r.GetStargazersCount(),
+		WatchersCount:   r.GetWatchersCount(),
+		ForksCount:      r.GetForksCount(),
+		OpenIssuesCount: r.GetOpenIssuesCount(),
+		Size:            r.GetSize(),
+		CreatedAt:       r.GetCreatedAt().Time,
+		UpdatedAt:       r.GetUpdatedAt().Time,
+		PushedAt:        r.GetPushedAt().Time,
+	}
+	if r.License != nil {
+		repo.License = &models.License{
+			Key:    r.License.GetKey(),
+			Name:   r.License.GetName(),
+			SPDXID: r.License.GetSPDXID(),
+		}
+	}
+	return repo
+}
+
+func repositoriesFromGitHub(rs []*github.Repository) []*models.Repository {
+	repos := make([]*models.Repository, 0, len(rs))
+	for _, r := range rs {
+		repos = append(repos, repositoryFromGitHub(r))
+	}
+	return repos
+}
+
+func releaseFromGitHub(r *github.RepositoryRelease) *models.Release {
+	if r == nil {
+		return nil
+	}
+	return &models.Release{
+		TagName:     r.GetTagName(),
+		Name:        r.GetName(),
+		HTMLURL:     r.GetHTMLURL(),
+		Prerelease:  r.GetPrerelease(),
+		PublishedAt: r.GetPublishedAt().Time,
+	}
+}
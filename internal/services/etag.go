@@ -0,0 +1,273 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits of the response cache. Long-running servers see an open-ended set of
+// logins, so memory holds only the most recently used responses and the cache
+// directory is pruned by age and size.
+const (
+	maxMemoryCacheBytes = 32 << 20
+	maxDiskCacheBytes   = 256 << 20
+	maxDiskCacheAge     = 30 * 24 * time.Hour
+)
+
+// conditionalTransport remembers the validators of successful GET responses and
+// revalidates later requests with If-None-Match / If-Modified-Since. A 304 from
+// GitHub does not count against the rate limit, so repeated refreshes of an
+// unchanged profile are almost free.
+type conditionalTransport struct {
+	base http.RoundTripper
+
+	// dir keeps responses between runs when set
+	dir       string
+	pruneOnce sync.Once
+
+	// entries indexes recent, most recently used first; size is the bytes they hold
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	recent  *list.List
+	size    int
+}
+
+// cachedResponse is a stored response together with its validators
+type cachedResponse struct {
+	key          string
+	etag         string
+	lastModified string
+	raw          []byte
+}
+
+func newConditionalTransport(base http.RoundTripper) *conditionalTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &conditionalTransport{
+		base:    base,
+		entries: make(map[string]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+
+	entry, cached := t.lookup(key)
+	if cached {
+		req = req.Clone(req.Context())
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached {
+		cachedResp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(entry.raw)), req)
+		if err != nil {
+			return resp, nil
+		}
+		// Keep the fresh rate limit and scope headers so callers see the current budget
+		for _, name := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-RateLimit-Used", "X-OAuth-Scopes"} {
+			if value := resp.Header.Get(name); value != "" {
+				cachedResp.Header.Set(name, value)
+			}
+		}
+		resp.Body.Close()
+		t.touch(key)
+		return cachedResp, nil
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		t.store(key, resp)
+	}
+
+	return resp, nil
+}
+
+// store records a response when it carries a validator
+func (t *conditionalTransport) store(key string, resp *http.Response) {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return
+	}
+
+	raw, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return
+	}
+
+	t.remember(cachedResponse{key: key, etag: etag, lastModified: lastModified, raw: raw})
+	t.persist(key, raw)
+}
+
+// remember keeps entry in memory, evicting the least recently used responses
+// beyond maxMemoryCacheBytes
+func (t *conditionalTransport) remember(entry cachedResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if el, ok := t.entries[entry.key]; ok {
+		t.size -= len(el.Value.(cachedResponse).raw)
+		t.recent.Remove(el)
+	}
+	t.entries[entry.key] = t.recent.PushFront(entry)
+	t.size += len(entry.raw)
+
+	for t.size > maxMemoryCacheBytes && t.recent.Len() > 1 {
+		oldest := t.recent.Back()
+		evicted := t.recent.Remove(oldest).(cachedResponse)
+		delete(t.entries, evicted.key)
+		t.size -= len(evicted.raw)
+	}
+}
+
+// lookup returns the stored response for key from memory or, failing that, the cache directory
+func (t *conditionalTransport) lookup(key string) (cachedResponse, bool) {
+	t.mu.Lock()
+	el, ok := t.entries[key]
+	if ok {
+		t.recent.MoveToFront(el)
+	}
+	t.mu.Unlock()
+	if ok {
+		return el.Value.(cachedResponse), true
+	}
+	if t.dir == "" {
+		return cachedResponse{}, false
+	}
+
+	raw, err := os.ReadFile(t.path(key))
+	if err != nil {
+		return cachedResponse{}, false
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(raw)), nil)
+	if err != nil {
+		return cachedResponse{}, false
+	}
+	resp.Body.Close()
+
+	entry := cachedResponse{
+		key:          key,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		raw:          raw,
+	}
+	t.remember(entry)
+	return entry, true
+}
+
+// persist writes a response to the cache directory. Responses may include
+// private data, so only the owner can read them. Failures only cost a
+// future revalidation and are ignored.
+func (t *conditionalTransport) persist(key string, raw []byte) {
+	if t.dir == "" {
+		return
+	}
+	if err := os.MkdirAll(t.dir, 0o700); err != nil {
+		return
+	}
+	t.pruneOnce.Do(t.prune)
+
+	// Write to a temporary file first so concurrent runs never read half a response
+	tmp, err := os.CreateTemp(t.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), t.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// path names the cache file of key
+func (t *conditionalTransport) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(t.dir, hex.EncodeToString(sum[:]))
+}
+
+// touch marks a response in the cache directory as used, so pruning keeps it
+func (t *conditionalTransport) touch(key string) {
+	if t.dir == "" {
+		return
+	}
+	now := time.Now()
+	_ = os.Chtimes(t.path(key), now, now)
+}
+
+// prune removes responses unused for maxDiskCacheAge and, beyond
+// maxDiskCacheBytes, the least recently used ones. Failures are ignored.
+func (t *conditionalTransport) prune() {
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		return
+	}
+
+	type file struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []file
+	var total int64
+	now := time.Now()
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		path := filepath.Join(t.dir, entry.Name())
+		// Temporary files left behind by an interrupted write
+		stale := strings.HasPrefix(entry.Name(), ".tmp-") && now.Sub(info.ModTime()) > time.Hour
+		if stale || now.Sub(info.ModTime()) > maxDiskCacheAge {
+			os.Remove(path)
+			continue
+		}
+		files = append(files, file{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, f := range files {
+		if total <= maxDiskCacheBytes {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+		}
+	}
+}
@@ -3,73 +3,202 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-github/v73/github"
 	"golang.org/x/oauth2"
 
 	"github-profiler/internal/models"
+	"github-profiler/internal/scoring"
 )
 
 // GitHubService handles all GitHub API interactions
 type GitHubService struct {
-	client *github.Client
-	ctx    context.Context
+	client    *github.Client
+	ctx       context.Context
+	scoring   *scoring.Model
+	reference *scoring.Distribution
+	explain   bool
+	api       *instrumentedTransport
+
+	// Settings applied when the client is built
+	baseURL     *url.URL
+	httpClient  *http.Client
+	concurrency int
+	cacheDir    string
+	app         *App
 }
 
-// NewGitHubService creates a new GitHub service instance
-func NewGitHubService(token string) *GitHubService {
-	ctx := context.Background()
-	var client *github.Client
+// Option configures a GitHubService
+type Option func(*GitHubService)
 
-	if token != "" {
-		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-		tc := oauth2.NewClient(ctx, ts)
-		client = github.NewClient(tc)
-	} else {
-		client = github.NewClient(nil)
+// WithScoring ranks profiles with the given scoring model instead of the built-in one
+func WithScoring(model *scoring.Model) Option {
+	return func(s *GitHubService) {
+		if model != nil {
+			s.scoring = model
+		}
+	}
+}
+
+// WithReference computes percentiles against the given reference distribution
+// instead of the bundled one built with the scoring rules, if any
+func WithReference(dist *scoring.Distribution) Option {
+	return func(s *GitHubService) {
+		if dist != nil {
+			s.reference = dist
+		}
+	}
+}
+
+// WithExplanation records how every ranking was computed
+func WithExplanation() Option {
+	return func(s *GitHubService) {
+		s.explain = true
+	}
+}
+
+// WithBaseURL sends API requests to a GitHub Enterprise Server or proxy, e.g.
+// https://github.example.com/api/v3/
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(s *GitHubService) {
+		if baseURL == nil {
+			return
+		}
+		u := *baseURL
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		s.baseURL = &u
+	}
+}
+
+// WithHTTPClient sends requests through client's transport and timeout
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *GitHubService) {
+		s.httpClient = client
+	}
+}
+
+// WithConcurrency fetches up to n repositories' languages at once
+func WithConcurrency(n int) Option {
+	return func(s *GitHubService) {
+		if n > 0 {
+			s.concurrency = n
+		}
+	}
+}
+
+// WithCacheDir keeps GitHub responses in dir so later runs can revalidate
+// them instead of downloading them again
+func WithCacheDir(dir string) Option {
+	return func(s *GitHubService) {
+		s.cacheDir = dir
+	}
+}
+
+// NewGitHubService creates a new GitHub service instance. The token is ignored
+// when WithApp is given.
+func NewGitHubService(token string, opts ...Option) *GitHubService {
+	s := &GitHubService{
+		ctx:         context.Background(),
+		scoring:     scoring.Default(),
+		concurrency: 1,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.reference == nil {
+		s.reference = scoring.BundledDistribution(s.scoring)
+	}
+
+	base := http.DefaultTransport
+	var timeout time.Duration
+	if s.httpClient != nil {
+		if s.httpClient.Transport != nil {
+			base = s.httpClient.Transport
+		}
+		timeout = s.httpClient.Timeout
+	}
+
+	// All requests are revalidated with conditional requests so refreshes are
+	// cheap; only those that reach GitHub are counted
+	s.api = newInstrumentedTransport(base)
+	conditional := newConditionalTransport(s.api)
+	conditional.dir = s.cacheDir
+	httpClient := &http.Client{Transport: conditional, Timeout: timeout}
+
+	var ts oauth2.TokenSource
+	switch {
+	case s.app != nil:
+		// Token exchanges bypass the response cache but are still counted
+		ts = newInstallationTokenSource(*s.app, s.baseURL, &http.Client{Transport: s.api, Timeout: timeout})
+	case token != "":
+		ts = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	}
 
-	return &GitHubService{
-		client: client,
-		ctx:    ctx,
+	if ts != nil {
+		tc := oauth2.NewClient(context.WithValue(s.ctx, oauth2.HTTPClient, httpClient), ts)
+		s.client = github.NewClient(tc)
+	} else {
+		s.client = github.NewClient(httpClient)
+	}
+	if s.baseURL != nil {
+		s.client.BaseURL = s.baseURL
 	}
+	return s
+}
+
+// APIStats reports the requests this service has sent to GitHub and the latest rate limit
+func (s *GitHubService) APIStats() APIStats {
+	return s.api.snapshot()
 }
 
 // GetUserProfile fetches comprehensive user profile data
 func (s *GitHubService) GetUserProfile(username string) (*models.UserProfile, error) {
+	return s.GetUserProfileContext(s.ctx, username)
+}
+
+// GetUserProfileContext is GetUserProfile with a context that can cancel the requests
+func (s *GitHubService) GetUserProfileContext(ctx context.Context, username string) (*models.UserProfile, error) {
 	// Fetch user basic info
-	user, _, err := s.client.Users.Get(s.ctx, username)
+	ghUser, _, err := s.client.Users.Get(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch user: %w", err)
 	}
+	user := userFromGitHub(ghUser)
 
 	// Fetch repositories
-	repos, err := s.fetchAllRepositories(username)
+	repos, err := s.fetchAllRepositories(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
 	// Calculate statistics
-	languages := s.calculateLanguageStats(repos, username)
+	languages := s.calculateLanguageStats(ctx, repos, username)
 	stats := s.calculateProfileStats(repos)
 	activity := s.calculateActivityStats(repos)
-	ranking := s.calculateRanking(user, repos, stats, activity)
+	ranking := s.calculateRanking(user, stats, activity, languages)
 
 	return &models.UserProfile{
-		User:         user,
-		Repositories: repos,
-		Languages:    languages,
-		Stats:        stats,
-		Activity:     activity,
-		Ranking:      ranking,
+		SchemaVersion: models.SchemaVersion,
+		User:          user,
+		Repositories:  repos,
+		Languages:     languages,
+		Stats:         stats,
+		Activity:      activity,
+		Ranking:       ranking,
 	}, nil
 }
 
 // fetchAllRepositories gets all repositories for a user
-func (s *GitHubService) fetchAllRepositories(username string) ([]*github.Repository, error) {
-	var allRepos []*github.Repository
+func (s *GitHubService) fetchAllRepositories(ctx context.Context, username string) ([]*models.Repository, error) {
+	var allRepos []*models.Repository
 
 	opts := &github.RepositoryListOptions{
 		Type:        "owner",
@@ -79,12 +208,12 @@ func (s *GitHubService) fetchAllRepositories(username string) ([]*github.Reposit
 	}
 
 	for {
-		repos, resp, err := s.client.Repositories.List(s.ctx, username, opts)
+		repos, resp, err := s.client.Repositories.List(ctx, username, opts)
 		if err != nil {
 			return nil, err
 		}
 
-		allRepos = append(allRepos, repos...)
+		allRepos = append(allRepos, repositoriesFromGitHub(repos)...)
 
 		if resp.NextPage == 0 {
 			break
@@ -96,28 +225,49 @@ func (s *GitHubService) fetchAllRepositories(username string) ([]*github.Reposit
 }
 
 // calculateLanguageStats analyzes programming language usage
-func (s *GitHubService) calculateLanguageStats(repos []*github.Repository, username string) models.LanguageStats {
+func (s *GitHubService) calculateLanguageStats(ctx context.Context, repos []*models.Repository, username string) models.LanguageStats {
 	languageBytes := make(map[string]int)
 	languageRepos := make(map[string]int)
 	totalBytes := 0
 
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		next = make(chan *models.Repository)
+	)
+	for range s.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range next {
+				languages, _, err := s.client.Repositories.ListLanguages(ctx, username, repo.Name)
+				if err == nil {
+					mu.Lock()
+					for lang, bytes := range languages {
+						languageBytes[lang] += bytes
+						languageRepos[lang]++
+						totalBytes += bytes
+					}
+					mu.Unlock()
+				}
+
+				// Rate limiting protection
+				time.Sleep(50 * time.Millisecond)
+			}
+		}()
+	}
+
 	for _, repo := range repos {
-		if repo.GetFork() || repo.GetPrivate() {
+		if repo.Fork || repo.Private {
 			continue
 		}
-
-		languages, _, err := s.client.Repositories.ListLanguages(s.ctx, username, repo.GetName())
-		if err == nil {
-			for lang, bytes := range languages {
-				languageBytes[lang] += bytes
-				languageRepos[lang]++
-				totalBytes += bytes
-			}
+		if ctx.Err() != nil {
+			break
 		}
-
-		// Rate limiting protection
-		time.Sleep(50 * time.Millisecond)
+		next <- repo
 	}
+	close(next)
+	wg.Wait()
 
 	// Convert to structured format
 	languageStats := make(map[string]models.LanguageInfo)
@@ -142,7 +292,7 @@ func (s *GitHubService) calculateLanguageStats(repos []*github.Repository, usern
 }
 
 // calculateProfileStats computes repository statistics
-func (s *GitHubService) calculateProfileStats(repos []*github.Repository) models.ProfileStats {
+func (s *GitHubService) calculateProfileStats(repos []*models.Repository) models.ProfileStats {
 	stats := models.ProfileStats{
 		RepoTypes:       make(map[string]int),
 		UpdateFrequency: make(map[string]int),
@@ -152,30 +302,30 @@ func (s *GitHubService) calculateProfileStats(repos []*github.Repository) models
 	totalRepos := 0
 
 	for _, repo := range repos {
-		if repo.GetFork() {
+		if repo.Fork {
 			stats.RepoTypes["forks"]++
 			continue
 		}
 
-		if repo.GetPrivate() {
+		if repo.Private {
 			stats.RepoTypes["private"]++
 		} else {
 			stats.RepoTypes["public"]++
 		}
 
-		stats.TotalStars += repo.GetStargazersCount()
-		stats.TotalForks += repo.GetForksCount()
-		stats.TotalSize += int64(repo.GetSize())
+		stats.TotalStars += repo.StargazersCount
+		stats.TotalForks += repo.ForksCount
+		stats.TotalSize += int64(repo.Size)
 
 		// Timeline analysis
-		if repo.CreatedAt != nil {
+		if !repo.CreatedAt.IsZero() {
 			year := repo.CreatedAt.Year()
 			yearCounts[year]++
 		}
 
 		// Update frequency analysis
-		if repo.UpdatedAt != nil {
-			daysSinceUpdate := int(time.Since(repo.UpdatedAt.Time).Hours() / 24)
+		if !repo.UpdatedAt.IsZero() {
+			daysSinceUpdate := int(time.Since(repo.UpdatedAt).Hours() / 24)
 			switch {
 			case daysSinceUpdate <= 7:
 				stats.UpdateFrequency["weekly"]++
@@ -216,7 +366,7 @@ func (s *GitHubService) calculateProfileStats(repos []*github.Repository) models
 }
 
 // calculateActivityStats computes user activity patterns
-func (s *GitHubService) calculateActivityStats(repos []*github.Repository) models.ActivityStats {
+func (s *GitHubService) calculateActivityStats(repos []*models.Repository) models.ActivityStats {
 	activity := models.ActivityStats{
 		CommitFrequency: make(map[string]int),
 		ProductiveHours: make(map[string]int),
@@ -224,10 +374,10 @@ func (s *GitHubService) calculateActivityStats(repos []*github.Repository) model
 
 	// Calculate contribution score based on repository activity
 	for _, repo := range repos {
-		if !repo.GetFork() {
-			activity.ContributionScore += float64(repo.GetStargazersCount()) * 0.5
-			activity.ContributionScore += float64(repo.GetForksCount()) * 0.3
-			activity.ContributionScore += float64(repo.GetWatchersCount()) * 0.2
+		if !repo.Fork {
+			activity.ContributionScore += float64(repo.StargazersCount) * 0.5
+			activity.ContributionScore += float64(repo.ForksCount) * 0.3
+			activity.ContributionScore += float64(repo.WatchersCount) * 0.2
 		}
 	}
 
@@ -235,78 +385,45 @@ func (s *GitHubService) calculateActivityStats(repos []*github.Repository) model
 }
 
 // calculateRanking determines the user's developer ranking
-func (s *GitHubService) calculateRanking(user *github.User, repos []*github.Repository, stats models.ProfileStats, activity models.ActivityStats) models.RankingInfo {
-	// Social Score (0-25 points)
-	socialScore := 0.0
-	followers := user.GetFollowers()
-	switch {
-	case followers >= 10000:
-		socialScore = 25.0
-	case followers >= 1000:
-		socialScore = 20.0
-	case followers >= 500:
-		socialScore = 15.0
-	case followers >= 100:
-		socialScore = 10.0
-	case followers >= 50:
-		socialScore = 7.5
-	case followers >= 10:
-		socialScore = 5.0
-	default:
-		socialScore = 2.5
-	}
-
-	// Code Score (0-30 points)
-	codeScore := 0.0
-	publicRepos := stats.RepoTypes["public"]
-	if publicRepos > 0 {
-		codeScore += float64(publicRepos) * 0.5
-		if codeScore > 15 {
-			codeScore = 15
-		}
-	}
-
-	if stats.TotalStars > 0 {
-		starScore := float64(stats.TotalStars) * 0.1
-		if starScore > 15 {
-			starScore = 15
-		}
-		codeScore += starScore
-	}
-
-	// Activity Score (0-25 points)
-	activityScore := activity.ContributionScore * 0.01
-	if activityScore > 25 {
-		activityScore = 25
-	}
+func (s *GitHubService) calculateRanking(user models.User, stats models.ProfileStats, activity models.ActivityStats, languages models.LanguageStats) models.RankingInfo {
+	return s.rank(scoring.Inputs{
+		User:      user,
+		Stats:     stats,
+		Activity:  activity,
+		Languages: languages,
+	})
+}
 
-	// Innovation Score (0-20 points)
-	innovationScore := 0.0
-	if stats.AvgStarsPerRepo > 0 {
-		innovationScore = stats.AvgStarsPerRepo * 0.5
-		if innovationScore > 20 {
-			innovationScore = 20
-		}
+// rank scores the inputs and places the result in the reference distribution
+func (s *GitHubService) rank(in scoring.Inputs) models.RankingInfo {
+	var ranking models.RankingInfo
+	if s.explain {
+		ranking = s.scoring.Explain(in)
+	} else {
+		ranking = s.scoring.Evaluate(in)
 	}
+	s.reference.Apply(&ranking, s.scoring)
+	return ranking
+}
 
-	totalScore := socialScore + codeScore + activityScore + innovationScore
-	percentile := (totalScore / 100.0) * 100
+// ScoringModel returns the model rankings are computed with
+func (s *GitHubService) ScoringModel() *scoring.Model {
+	return s.scoring
+}
 
-	rank := models.GetRankByScore(totalScore)
+// Rescore recomputes the ranking of a stored profile with the active scoring model
+func (s *GitHubService) Rescore(profile *models.UserProfile) models.RankingInfo {
+	return s.rank(scoring.InputsFromProfile(profile))
+}
 
-	return models.RankingInfo{
-		OverallRank:     rank.Name,
-		Badge:           rank.Badge,
-		TotalScore:      totalScore,
-		Percentile:      percentile,
-		SocialScore:     socialScore,
-		CodeScore:       codeScore,
-		ActivityScore:   activityScore,
-		InnovationScore: innovationScore,
-	}
+// GetDemoRepositoryDetail returns mock repository details for demo purposes
+func (s *GitHubService) GetDemoRepositoryDetail(repo *models.Repository) (*models.RepositoryDetail, error) {
+	return CreateMockRepositoryDetail(repo), nil
 }
 
-// GetDemoProfile returns mock data for demo purposes
+// GetDemoProfile returns mock data for demo purposes, ranked with the active scoring model
 func (s *GitHubService) GetDemoProfile() (*models.UserProfile, error) {
-	return CreateMockProfile(), nil
+	profile := CreateMockProfile()
+	profile.Ranking = s.Rescore(profile)
+	return profile, nil
 }
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// APIStats describes the GitHub API traffic of a service
+type APIStats struct {
+	// Requests counts requests sent to GitHub by response status code, with
+	// "error" for requests that got no response
+	Requests map[string]int64
+
+	// RateLimit, RateRemaining and RateReset come from the rate limit headers
+	// of the latest response; RateKnown is false until a response carried them
+	RateKnown     bool
+	RateLimit     int
+	RateRemaining int
+	RateReset     time.Time
+}
+
+// instrumentedTransport records every request that reaches the network and
+// the rate limit headers GitHub returns with it
+type instrumentedTransport struct {
+	base http.RoundTripper
+
+	mu    sync.Mutex
+	stats APIStats
+}
+
+func newInstrumentedTransport(base http.RoundTripper) *instrumentedTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &instrumentedTransport{
+		base:  base,
+		stats: APIStats{Requests: make(map[string]int64)},
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.stats.Requests["error"]++
+		return resp, err
+	}
+	t.stats.Requests[strconv.Itoa(resp.StatusCode)]++
+
+	limit, limitErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	reset, resetErr := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if limitErr == nil && remainingErr == nil && resetErr == nil {
+		t.stats.RateKnown = true
+		t.stats.RateLimit = limit
+		t.stats.RateRemaining = remaining
+		t.stats.RateReset = time.Unix(reset, 0)
+	}
+
+	return resp, nil
+}
+
+// snapshot returns a copy of the stats that is safe to read while requests continue
+func (t *instrumentedTransport) snapshot() APIStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.Requests = make(map[string]int64, len(t.stats.Requests))
+	for code, n := range t.stats.Requests {
+		stats.Requests[code] = n
+	}
+	return stats
+}
@@ -1,39 +1,27 @@
 package services
 
 import (
+	"strings"
 	"time"
 
-	"github.com/google/go-github/v73/github"
-
 	"github-profiler/internal/models"
 )
 
 // CreateMockProfile creates sample data for demo purposes
 func CreateMockProfile() *models.UserProfile {
 	// Create mock user
-	name := "Demo Developer"
-	bio := "Full-stack developer passionate about open source and clean code"
-	company := "TechCorp Solutions"
-	location := "San Francisco, CA"
-	blog := "https://demo-developer.dev"
-	twitterUsername := "demo_dev"
-	publicRepos := 45
-	followers := 1250
-	following := 180
-	createdAt := time.Date(2018, 3, 15, 0, 0, 0, 0, time.UTC)
-
-	user := &github.User{
-		Login:           github.String("demo-user"),
-		Name:            &name,
-		Bio:             &bio,
-		Company:         &company,
-		Location:        &location,
-		Blog:            &blog,
-		TwitterUsername: &twitterUsername,
-		PublicRepos:     &publicRepos,
-		Followers:       &followers,
-		Following:       &following,
-		CreatedAt:       &github.Timestamp{Time: createdAt},
+	user := models.User{
+		Login:           "demo-user",
+		Name:            "Demo Developer",
+		Bio:             "Full-stack developer passionate about open source and clean code",
+		Company:         "TechCorp Solutions",
+		Location:        "San Francisco, CA",
+		Blog:            "https://demo-developer.dev",
+		TwitterUsername: "demo_dev",
+		PublicRepos:     45,
+		Followers:       1250,
+		Following:       180,
+		CreatedAt:       time.Date(2018, 3, 15, 0, 0, 0, 0, time.UTC),
 	}
 
 	// Create mock repositories
@@ -111,7 +99,6 @@ func CreateMockProfile() *models.UserProfile {
 		OverallRank:     "Experienced Developer",
 		Badge:           "EXPERIENCED",
 		TotalScore:      78.5,
-		Percentile:      78.5,
 		SocialScore:     18.0,
 		CodeScore:       24.5,
 		ActivityScore:   19.0,
@@ -119,17 +106,18 @@ func CreateMockProfile() *models.UserProfile {
 	}
 
 	return &models.UserProfile{
-		User:         user,
-		Repositories: repos,
-		Languages:    languages,
-		Stats:        stats,
-		Activity:     activity,
-		Ranking:      ranking,
+		SchemaVersion: models.SchemaVersion,
+		User:          user,
+		Repositories:  repos,
+		Languages:     languages,
+		Stats:         stats,
+		Activity:      activity,
+		Ranking:       ranking,
 	}
 }
 
-func createMockRepositories() []*github.Repository {
-	repos := []*github.Repository{}
+func createMockRepositories() []*models.Repository {
+	repos := []*models.Repository{}
 
 	// Sample repository data
 	repoData := []struct {
@@ -151,27 +139,20 @@ func createMockRepositories() []*github.Repository {
 	}
 
 	for _, data := range repoData {
-		name := data.name
-		desc := data.description
-		lang := data.language
-		stars := data.stars
-		forks := data.forks
-		size := data.size
-		isPrivate := false
-		isFork := false
-		updatedAt := time.Now().AddDate(0, -1, 0) // 1 month ago
-
-		repo := &github.Repository{
-			Name:            &name,
-			Description:     &desc,
-			Language:        &lang,
-			StargazersCount: &stars,
-			ForksCount:      &forks,
-			Size:            &size,
-			Private:         &isPrivate,
-			Fork:            &isFork,
-			UpdatedAt:       &github.Timestamp{Time: updatedAt},
-			CreatedAt:       &github.Timestamp{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		repo := &models.Repository{
+			Name:            data.name,
+			FullName:        "demo-user/" + data.name,
+			Topics:          []string{strings.ToLower(data.language), "open-source"},
+			License:         &models.License{Name: "MIT License", SPDXID: "MIT"},
+			DefaultBranch:   "main",
+			OpenIssuesCount: data.forks / 4,
+			Description:     data.description,
+			Language:        data.language,
+			StargazersCount: data.stars,
+			ForksCount:      data.forks,
+			Size:            data.size,
+			UpdatedAt:       time.Now().AddDate(0, -1, 0), // 1 month ago
+			CreatedAt:       time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
 		}
 
 		repos = append(repos, repo)
@@ -179,3 +160,36 @@ func createMockRepositories() []*github.Repository {
 
 	return repos
 }
+
+// CreateMockRepositoryDetail creates sample drill-down data for a mock repository
+func CreateMockRepositoryDetail(repo *models.Repository) *models.RepositoryDetail {
+	language := repo.Language
+	size := repo.Size * 1024
+
+	release := &models.Release{
+		TagName:     "v1.4.0",
+		Name:        "Performance improvements",
+		PublishedAt: time.Now().AddDate(0, -2, 0),
+	}
+
+	readme := "# " + repo.Name + "\n\n" + repo.Description + `.
+
+## Getting Started
+
+Clone the repository and follow the instructions below to run it locally.
+
+## Contributing
+
+Pull requests are welcome. For major changes, please open an issue first.`
+
+	return &models.RepositoryDetail{
+		Repository: repo,
+		Languages: map[string]int{
+			language:   size * 85 / 100,
+			"Shell":    size * 10 / 100,
+			"Makefile": size * 5 / 100,
+		},
+		LatestRelease: release,
+		ReadmeExcerpt: readme,
+	}
+}
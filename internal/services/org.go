@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v73/github"
+
+	"github-profiler/internal/models"
+)
+
+// maxOrgMembers bounds the public members listed for an organization
+const maxOrgMembers = 100
+
+// GetOrgProfile fetches an organization together with statistics over its public repositories
+func (s *GitHubService) GetOrgProfile(login string) (*models.OrgProfile, error) {
+	return s.GetOrgProfileContext(s.ctx, login)
+}
+
+// GetOrgProfileContext is GetOrgProfile with a context that can cancel the requests
+func (s *GitHubService) GetOrgProfileContext(ctx context.Context, login string) (*models.OrgProfile, error) {
+	ghOrg, _, err := s.client.Organizations.Get(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch organization: %w", err)
+	}
+
+	repos, err := s.fetchOrgRepositories(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
+	}
+
+	// Members are a nice-to-have; organizations can hide them
+	var members []string
+	users, _, err := s.client.Organizations.ListMembers(ctx, login, &github.ListMembersOptions{
+		PublicOnly:  true,
+		ListOptions: github.ListOptions{PerPage: maxOrgMembers},
+	})
+	if err == nil {
+		for _, user := range users {
+			members = append(members, user.GetLogin())
+		}
+	}
+
+	return &models.OrgProfile{
+		SchemaVersion: models.SchemaVersion,
+		Organization:  organizationFromGitHub(ghOrg),
+		Repositories:  repos,
+		Languages:     s.calculateLanguageStats(ctx, repos, login),
+		Stats:         s.calculateProfileStats(repos),
+		Members:       members,
+	}, nil
+}
+
+// fetchOrgRepositories gets all public repositories of an organization
+func (s *GitHubService) fetchOrgRepositories(ctx context.Context, org string) ([]*models.Repository, error) {
+	var allRepos []*models.Repository
+
+	opts := &github.RepositoryListByOrgOptions{
+		Type:        "public",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		repos, resp, err := s.client.Repositories.ListByOrg(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		allRepos = append(allRepos, repositoriesFromGitHub(repos)...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allRepos, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v73/github"
+
+	"github-profiler/internal/models"
+)
+
+// readmeExcerptLines bounds how much of a README is kept for the detail view
+const readmeExcerptLines = 20
+
+// GetRepositoryDetail fetches the data shown when drilling into a single repository
+func (s *GitHubService) GetRepositoryDetail(repo *models.Repository, fallbackOwner string) (*models.RepositoryDetail, error) {
+	return s.GetRepositoryDetailContext(s.ctx, repo, fallbackOwner)
+}
+
+// GetRepositoryDetailContext is GetRepositoryDetail with a context that can cancel the requests
+func (s *GitHubService) GetRepositoryDetailContext(ctx context.Context, repo *models.Repository, fallbackOwner string) (*models.RepositoryDetail, error) {
+	owner := repo.Owner.Login
+	if owner == "" {
+		owner = fallbackOwner
+	}
+	name := repo.Name
+
+	languages, _, err := s.client.Repositories.ListLanguages(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch languages: %w", err)
+	}
+
+	// Repositories without releases or a README answer 404, which is not an error here
+	release, _, err := s.client.Repositories.GetLatestRelease(ctx, owner, name)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+
+	var excerpt string
+	readme, _, err := s.client.Repositories.GetReadme(ctx, owner, name, nil)
+	switch {
+	case err == nil:
+		content, err := readme.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode README: %w", err)
+		}
+		excerpt = readmeExcerpt(content)
+	case !isNotFound(err):
+		return nil, fmt.Errorf("failed to fetch README: %w", err)
+	}
+
+	return &models.RepositoryDetail{
+		Repository:    repo,
+		Languages:     languages,
+		LatestRelease: releaseFromGitHub(release),
+		ReadmeExcerpt: excerpt,
+	}, nil
+}
+
+// isNotFound reports whether err is a GitHub 404 response
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// readmeExcerpt keeps the first non-empty lines of a README
+func readmeExcerpt(content string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if len(lines) == 0 && strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+		if len(lines) == readmeExcerptLines {
+			break
+		}
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
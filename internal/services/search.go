@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v73/github"
+)
+
+// SearchUsers returns logins matching a partial name, best matches first
+func (s *GitHubService) SearchUsers(query string, limit int) ([]string, error) {
+	return s.SearchUsersContext(s.ctx, query, limit)
+}
+
+// SearchUsersContext is SearchUsers with a context that can cancel the request
+func (s *GitHubService) SearchUsersContext(ctx context.Context, query string, limit int) ([]string, error) {
+	opts := &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: limit},
+	}
+
+	result, _, err := s.client.Search.Users(ctx, query+" in:login", opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	logins := make([]string, 0, len(result.Users))
+	for _, user := range result.Users {
+		logins = append(logins, user.GetLogin())
+	}
+	return logins, nil
+}
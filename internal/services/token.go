@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"strings"
+)
+
+// TokenInfo describes the account and permissions behind the service's token
+type TokenInfo struct {
+	Login string
+
+	// Scopes lists the OAuth scopes of a classic token. ScopesReported is false
+	// for fine-grained and GitHub App tokens, which GitHub reports no scopes for.
+	Scopes         []string
+	ScopesReported bool
+
+	APIStats
+}
+
+// TokenInfo looks up the authenticated user and the scopes granted to the token.
+// A GitHub App installation has no user, so only its token is checked.
+func (s *GitHubService) TokenInfo(ctx context.Context) (*TokenInfo, error) {
+	if s.app != nil {
+		if _, _, err := s.client.RateLimit.Get(ctx); err != nil {
+			return nil, err
+		}
+		return &TokenInfo{APIStats: s.APIStats()}, nil
+	}
+
+	user, resp, err := s.client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TokenInfo{Login: user.GetLogin()}
+	if header, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		info.ScopesReported = true
+		for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				info.Scopes = append(info.Scopes, scope)
+			}
+		}
+	}
+	info.APIStats = s.APIStats()
+	return info, nil
+}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github-profiler/internal/models"
+)
+
+// detailWidth is the widest the detail pane text grows
+const detailWidth = 80
+
+// paneKind identifies a pane pushed on the navigation stack
+type paneKind int
+
+const (
+	paneRepositoryDetail paneKind = iota
+)
+
+// pane is a sub-view shown on top of the profile views until Esc pops it
+type pane struct {
+	kind    paneKind
+	repo    *models.Repository
+	detail  *models.RepositoryDetail
+	loading bool
+	err     error
+}
+
+// Repository detail messages
+type RepoDetailFetchedMsg struct {
+	Detail *models.RepositoryDetail
+}
+
+type RepoDetailErrorMsg struct {
+	Repo  *models.Repository
+	Error error
+}
+
+// paneLoading reports whether any pane is still waiting for data
+func (m Model) paneLoading() bool {
+	for _, p := range m.panes {
+		if p.loading {
+			return true
+		}
+	}
+	return false
+}
+
+// pushPane opens a pane on top of the current view
+func (m Model) pushPane(p pane) Model {
+	m.panes = append(m.panes, p)
+	return m
+}
+
+// popPane returns to the view below the top pane
+func (m Model) popPane() Model {
+	if len(m.panes) > 0 {
+		m.panes = m.panes[:len(m.panes)-1]
+	}
+	return m
+}
+
+// topPane returns the pane currently shown, if any
+func (m Model) topPane() (pane, bool) {
+	if len(m.panes) == 0 {
+		return pane{}, false
+	}
+	return m.panes[len(m.panes)-1], true
+}
+
+// openRepository pushes a detail pane for repo and starts loading its details
+func (m Model) openRepository(repo *models.Repository) (Model, tea.Cmd) {
+	m = m.pushPane(pane{kind: paneRepositoryDetail, repo: repo, loading: true})
+	return m, tea.Batch(m.spinner.Tick, m.fetchRepositoryDetail(repo))
+}
+
+// fetchRepositoryDetail is a command that loads the drill-down data of a repository
+func (m Model) fetchRepositoryDetail(repo *models.Repository) tea.Cmd {
+	return func() tea.Msg {
+		var detail *models.RepositoryDetail
+		var err error
+
+		if m.username == "demo-user" {
+			detail = m.client.DemoRepository(repo)
+		} else {
+			detail, err = m.client.Repository(context.Background(), repo, m.profile.User.Login)
+		}
+
+		if err != nil {
+			return RepoDetailErrorMsg{Repo: repo, Error: err}
+		}
+		return RepoDetailFetchedMsg{Detail: detail}
+	}
+}
+
+// updatePane stores loaded details in the matching pane on the stack
+func (m Model) updatePane(repo *models.Repository, detail *models.RepositoryDetail, err error) Model {
+	for i := range m.panes {
+		if m.panes[i].kind == paneRepositoryDetail && m.panes[i].repo == repo {
+			m.panes[i].loading = false
+			m.panes[i].detail = detail
+			m.panes[i].err = err
+		}
+	}
+	return m
+}
+
+// renderPane draws the pane on top of the navigation stack
+func (m Model) renderPane(p pane) string {
+	switch p.kind {
+	case paneRepositoryDetail:
+		return m.renderRepositoryDetail(p)
+	default:
+		return ""
+	}
+}
+
+func (m Model) renderRepositoryDetail(p pane) string {
+	repo := p.repo
+	muted := m.theme.muted()
+	heading := m.theme.heading()
+
+	name := repo.FullName
+	if name == "" {
+		name = repo.Name
+	}
+	title := heading.Render(stripControl(name))
+	if flags := describeFlags(repo); flags != "" {
+		title += muted.Render(" [" + flags + "]")
+	}
+
+	width := detailWidth
+	if contentWidth := m.contentWidth(); contentWidth > 0 {
+		width = min(width, contentWidth)
+	}
+
+	description := lipgloss.NewStyle().
+		Width(width).
+		Render(getStringValue(stripControl(repo.Description)))
+
+	license := "None"
+	if repo.License != nil {
+		license = repo.License.Name
+	}
+	topics := "None"
+	if len(repo.Topics) > 0 {
+		topics = strings.Join(repo.Topics, ", ")
+	}
+
+	facts := fmt.Sprintf(`Topics: %s
+License: %s
+Default Branch: %s
+Open Issues: %d
+Stars: %d  Forks: %d  Watchers: %d  Size: %s
+Created: %s  Updated: %s`,
+		topics,
+		license,
+		repo.DefaultBranch,
+		repo.OpenIssuesCount,
+		repo.StargazersCount,
+		repo.ForksCount,
+		repo.WatchersCount,
+		formatSize(repo.Size),
+		repo.CreatedAt.Format("Jan 2, 2006"),
+		repo.UpdatedAt.Format("Jan 2, 2006"))
+
+	sections := []string{title, description, facts}
+
+	switch {
+	case p.loading:
+		sections = append(sections, fmt.Sprintf("%s Loading languages, release and README...", m.spinner.View()))
+	case p.err != nil:
+		sections = append(sections, m.theme.errorText().
+			Render("Failed to load details: "+p.err.Error()))
+	case p.detail != nil:
+		sections = append(sections,
+			heading.Render("Languages")+"\n"+renderLanguageBytes(p.detail.Languages),
+			heading.Render("Latest Release")+"\n"+renderRelease(p.detail.LatestRelease),
+			heading.Render("README")+"\n"+renderReadme(p.detail.ReadmeExcerpt, width))
+	}
+
+	sections = append(sections, m.help.ShortHelpView([]key.Binding{m.keys.Back}))
+
+	return strings.Join(sections, "\n\n")
+}
+
+// renderLanguageBytes draws the byte breakdown of a single repository
+func renderLanguageBytes(languages map[string]int) string {
+	if len(languages) == 0 {
+		return "No language data available"
+	}
+
+	total := 0
+	names := make([]string, 0, len(languages))
+	for name, bytes := range languages {
+		total += bytes
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if languages[names[i]] != languages[names[j]] {
+			return languages[names[i]] > languages[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var lines []string
+	for _, name := range names {
+		var percentage float64
+		if total > 0 {
+			percentage = float64(languages[name]) / float64(total) * 100
+		}
+
+		barWidth := 20
+		fillWidth := int((percentage / 100.0) * float64(barWidth))
+		bar := strings.Repeat("█", fillWidth) + strings.Repeat("░", barWidth-fillWidth)
+
+		lines = append(lines, fmt.Sprintf("%-12s %s %5.1f%% (%s)", name, bar, percentage, formatBytes(languages[name])))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderRelease(release *models.Release) string {
+	if release == nil {
+		return "No releases published"
+	}
+
+	text := stripControl(release.TagName)
+	if name := stripControl(release.Name); name != "" && name != text {
+		text += " - " + name
+	}
+	if !release.PublishedAt.IsZero() {
+		text += fmt.Sprintf(" (%s)", release.PublishedAt.Format("Jan 2, 2006"))
+	}
+	return text
+}
+
+func renderReadme(excerpt string, width int) string {
+	if excerpt == "" {
+		return "No README found"
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1).
+		Width(width).
+		Render(stripControl(excerpt))
+}
+
+// stripControl removes control characters other than newlines and tabs from
+// text written by other users, so escape sequences in a repository cannot
+// drive the terminal
+func stripControl(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// describeFlags spells out the fork, archived and private markers
+func describeFlags(repo *models.Repository) string {
+	var flags []string
+	if repo.Fork {
+		flags = append(flags, "fork")
+	}
+	if repo.Archived {
+		flags = append(flags, "archived")
+	}
+	if repo.Private {
+		flags = append(flags, "private")
+	}
+	return strings.Join(flags, ", ")
+}
+
+// formatBytes renders a byte count with a binary unit
+func formatBytes(bytes int) string {
+	switch {
+	case bytes >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+	case bytes >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github-profiler/internal/history"
+	"github-profiler/internal/models"
+	"github-profiler/internal/output"
+)
+
+// exportFormat is a file format the loaded profile can be written as
+type exportFormat struct {
+	name  string
+	ext   string
+	write func(w io.Writer, profile *models.UserProfile, snapshots []history.Snapshot) error
+}
+
+// exportFormats lists the formats offered by the export menu, in menu order
+var exportFormats = []exportFormat{
+	{"JSON", "json", func(w io.Writer, profile *models.UserProfile, _ []history.Snapshot) error {
+		return output.WriteJSON(w, profile)
+	}},
+	{"Markdown", "md", func(w io.Writer, profile *models.UserProfile, _ []history.Snapshot) error {
+		return output.WriteMarkdown(w, profile)
+	}},
+	{"HTML", "html", output.WriteHTML},
+	{"SVG card", "svg", func(w io.Writer, profile *models.UserProfile, _ []history.Snapshot) error {
+		return output.WriteSVGCard(w, profile)
+	}},
+}
+
+// exportDialog picks a format and then a filename for the current profile
+type exportDialog struct {
+	cursor   int
+	naming   bool
+	filename textinput.Model
+
+	// overwrite is an existing file the user was asked about; saving to it
+	// again confirms replacing it
+	overwrite string
+}
+
+// exportedMsg reports the outcome of writing an export
+type exportedMsg struct {
+	format string
+	path   string
+	err    error
+}
+
+// openExport shows the export menu
+func (m Model) openExport() Model {
+	filename := textinput.New()
+	filename.Prompt = "File: "
+	filename.CharLimit = 256
+
+	m.export = &exportDialog{filename: filename}
+	return m
+}
+
+// handleExportKey drives the export menu while it is open
+func (m Model) handleExportKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	dialog := *m.export
+
+	if dialog.naming {
+		switch msg.String() {
+		case "esc":
+			dialog.naming = false
+			dialog.overwrite = ""
+			dialog.filename.Blur()
+		case "enter":
+			path := expandHome(strings.TrimSpace(dialog.filename.Value()))
+			if path == "" {
+				return m, nil
+			}
+			// Ask before replacing a file; a second enter confirms
+			if dialog.overwrite != path {
+				if _, err := os.Stat(path); err == nil {
+					dialog.overwrite = path
+					m.export = &dialog
+					return m, nil
+				}
+			}
+			m.export = nil
+			return m, m.writeExport(exportFormats[dialog.cursor], path, dialog.overwrite == path)
+		default:
+			var cmd tea.Cmd
+			dialog.overwrite = ""
+			dialog.filename, cmd = dialog.filename.Update(msg)
+			m.export = &dialog
+			return m, cmd
+		}
+		m.export = &dialog
+		return m, nil
+	}
+
+	// Digits pick a format directly, shadowing the view jumps while the menu is open
+	if n := msg.String(); len(n) == 1 && n[0] >= '1' && int(n[0]-'1') < len(exportFormats) {
+		dialog.cursor = int(n[0] - '1')
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Export):
+		m.export = nil
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		if dialog.cursor > 0 {
+			dialog.cursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if dialog.cursor < len(exportFormats)-1 {
+			dialog.cursor++
+		}
+	case msg.Type == tea.KeyEnter || key.Matches(msg, m.keys.Select):
+		format := exportFormats[dialog.cursor]
+		dialog.naming = true
+		dialog.filename.SetValue(fmt.Sprintf("%s.%s", m.profile.User.Login, format.ext))
+		dialog.filename.CursorEnd()
+		m.export = &dialog
+		return m, dialog.filename.Focus()
+	}
+
+	m.export = &dialog
+	return m, nil
+}
+
+// writeExport is a command that writes the loaded profile without fetching it
+// again. An existing file is only replaced when overwrite is set.
+func (m Model) writeExport(format exportFormat, path string, overwrite bool) tea.Cmd {
+	profile := m.profile
+	snapshots := m.history
+
+	return func() tea.Msg {
+		flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		if overwrite {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		}
+		file, err := os.OpenFile(path, flags, 0o644)
+		if err != nil {
+			return exportedMsg{format: format.name, path: path, err: err}
+		}
+
+		err = format.write(file, profile, snapshots)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return exportedMsg{format: format.name, path: path, err: err}
+	}
+}
+
+// expandHome replaces a leading ~/ with the home directory
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// describeExport turns an export result into a status message
+func describeExport(msg exportedMsg) (string, bool) {
+	if msg.err != nil {
+		return fmt.Sprintf("Export failed: %v", msg.err), true
+	}
+	return fmt.Sprintf("Exported %s to %s", msg.format, msg.path), false
+}
+
+// renderExport draws the export menu or the filename prompt
+func (m Model) renderExport() string {
+	heading := m.theme.heading()
+	muted := m.theme.muted()
+	selected := m.theme.selected()
+
+	var lines []string
+	lines = append(lines, heading.Render("Export "+m.profile.User.Login), "")
+
+	for i, format := range exportFormats {
+		line := fmt.Sprintf(" %d  %-9s .%s ", i+1, format.name, format.ext)
+		if i == m.export.cursor {
+			line = selected.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "")
+	if m.export.naming {
+		lines = append(lines, m.export.filename.View(), "")
+		if m.export.overwrite != "" {
+			lines = append(lines, m.theme.errorText().Render(m.export.overwrite+" exists"), muted.Render("enter overwrite • esc back"))
+		} else {
+			lines = append(lines, muted.Render("enter save • esc back"))
+		}
+	} else {
+		lines = append(lines, m.help.ShortHelpView([]key.Binding{m.keys.Up, m.keys.Down, m.keys.Select, m.keys.Back}))
+	}
+
+	return m.theme.border().
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds every key binding of the TUI
+type keyMap struct {
+	Quit     key.Binding
+	Help     key.Binding
+	PrevView key.Binding
+	NextView key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Select   key.Binding
+	Back     key.Binding
+	Open     key.Binding
+	Copy     key.Binding
+	Export   key.Binding
+	Explain  key.Binding
+	Refresh  key.Binding
+
+	// Scrolling content taller than the terminal
+	ScrollUp   key.Binding
+	ScrollDown key.Binding
+
+	// Profile tabs
+	NewTab   key.Binding
+	PrevTab  key.Binding
+	NextTab  key.Binding
+	CloseTab key.Binding
+
+	// Repositories view
+	Filter   key.Binding
+	Sort     key.Binding
+	Forks    key.Binding
+	Archived key.Binding
+	Private  key.Binding
+
+	// Direct jumps, in the order of GetViews
+	Overview     key.Binding
+	Repositories key.Binding
+	Languages    key.Binding
+	Activity     key.Binding
+	Ranking      key.Binding
+}
+
+// defaultKeyMap binds both arrow keys and their vim equivalents
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Quit:     binding("quit", "q", "ctrl+c"),
+		Help:     binding("help", "?"),
+		PrevView: binding("previous view", "left", "h"),
+		NextView: binding("next view", "right", "l"),
+		Up:       binding("up", "up", "k"),
+		Down:     binding("down", "down", "j"),
+		Select:   binding("select", "enter"),
+		Back:     binding("back", "esc", "backspace"),
+		Open:     binding("open in browser", "o"),
+		Copy:     binding("copy URL", "y"),
+		Export:   binding("export", "e"),
+		Explain:  binding("explain ranking", "x"),
+		Refresh:  binding("refresh", "r"),
+
+		ScrollUp:   binding("scroll up", "pgup", "ctrl+u"),
+		ScrollDown: binding("scroll down", "pgdown", "ctrl+d"),
+
+		NewTab:   binding("new tab", "n"),
+		PrevTab:  binding("previous tab", "["),
+		NextTab:  binding("next tab", "]"),
+		CloseTab: binding("close tab", "ctrl+w"),
+
+		Filter:   binding("filter", "/"),
+		Sort:     binding("sort", "s"),
+		Forks:    binding("toggle forks", "f"),
+		Archived: binding("toggle archived", "a"),
+		Private:  binding("toggle private", "p"),
+
+		Overview:     binding("overview", "1"),
+		Repositories: binding("repositories", "2"),
+		Languages:    binding("languages", "3"),
+		Activity:     binding("activity", "4"),
+		Ranking:      binding("ranking", "5"),
+	}
+}
+
+// binding creates a key binding whose help lists all of its keys
+func binding(desc string, keys ...string) key.Binding {
+	b := key.NewBinding(key.WithKeys(keys...))
+	b.SetHelp(helpKeys(keys), desc)
+	return b
+}
+
+// helpKeys renders keys for the help view, using arrows for the cursor keys
+func helpKeys(keys []string) string {
+	symbols := map[string]string{"left": "←", "right": "→", "up": "↑", "down": "↓"}
+
+	shown := make([]string, len(keys))
+	for i, k := range keys {
+		if symbol, ok := symbols[k]; ok {
+			k = symbol
+		}
+		shown[i] = k
+	}
+	return strings.Join(shown, "/")
+}
+
+// actions maps the action names used in the config file to their bindings
+func (k *keyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":              &k.Quit,
+		"help":              &k.Help,
+		"previous_view":     &k.PrevView,
+		"next_view":         &k.NextView,
+		"up":                &k.Up,
+		"down":              &k.Down,
+		"select":            &k.Select,
+		"back":              &k.Back,
+		"open":              &k.Open,
+		"copy":              &k.Copy,
+		"export":            &k.Export,
+		"explain":           &k.Explain,
+		"refresh":           &k.Refresh,
+		"scroll_up":         &k.ScrollUp,
+		"scroll_down":       &k.ScrollDown,
+		"new_tab":           &k.NewTab,
+		"previous_tab":      &k.PrevTab,
+		"next_tab":          &k.NextTab,
+		"close_tab":         &k.CloseTab,
+		"filter":            &k.Filter,
+		"sort":              &k.Sort,
+		"toggle_forks":      &k.Forks,
+		"toggle_archived":   &k.Archived,
+		"toggle_private":    &k.Private,
+		"view_overview":     &k.Overview,
+		"view_repositories": &k.Repositories,
+		"view_languages":    &k.Languages,
+		"view_activity":     &k.Activity,
+		"view_ranking":      &k.Ranking,
+	}
+}
+
+// override rebinds actions by name. An empty key list disables the action.
+// Ctrl+C always quits so a bad keymap can never trap the user.
+func (k keyMap) override(overrides map[string][]string) (keyMap, error) {
+	actions := k.actions()
+
+	for name, keys := range overrides {
+		b, ok := actions[name]
+		if !ok {
+			names := make([]string, 0, len(actions))
+			for action := range actions {
+				names = append(names, action)
+			}
+			sort.Strings(names)
+			return k, fmt.Errorf("unknown keymap action %q (valid actions: %s)", name, strings.Join(names, ", "))
+		}
+
+		if name == "quit" && !slices.Contains(keys, "ctrl+c") {
+			keys = append(keys, "ctrl+c")
+		}
+		if len(keys) == 0 {
+			b.Unbind()
+			continue
+		}
+
+		desc := b.Help().Desc
+		b.SetKeys(keys...)
+		b.SetEnabled(true)
+		b.SetHelp(helpKeys(keys), desc)
+	}
+
+	return k, nil
+}
+
+// viewBindings returns the direct jump bindings in the order of GetViews
+func (k keyMap) viewBindings() []key.Binding {
+	return []key.Binding{k.Overview, k.Repositories, k.Languages, k.Activity, k.Ranking}
+}
+
+// FullHelp implements help.KeyMap for the ? overlay
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		append([]key.Binding{k.PrevView, k.NextView}, k.viewBindings()...),
+		{k.Up, k.Down, k.Select, k.Back, k.ScrollUp, k.ScrollDown},
+		{k.Open, k.Copy, k.Export, k.Explain, k.Refresh},
+		{k.NewTab, k.PrevTab, k.NextTab, k.CloseTab},
+		{k.Filter, k.Sort, k.Forks, k.Archived, k.Private},
+		{k.Help, k.Quit},
+	}
+}
+
+// ShortHelp implements help.KeyMap for the footer
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.PrevView, k.NextView, k.Open, k.Copy, k.Export, k.NewTab, k.Refresh, k.Help, k.Quit}
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dashboardMinWidth is the terminal width from which Overview shows the
+// overview, languages and ranking side by side
+const dashboardMinWidth = 150
+
+// sidePanelMinWidth is the terminal width from which the watch changelog sits
+// beside the content instead of below it
+const sidePanelMinWidth = 110
+
+// changelogWidth is the width the changelog panel takes, including its frame
+const changelogWidth = 40
+
+// contentWidth is the width available to the active view, or 0 before the
+// terminal size is known
+func (m Model) contentWidth() int {
+	width := m.width
+	if m.watching() && width >= sidePanelMinWidth {
+		width -= changelogWidth
+	}
+	return max(width, 0)
+}
+
+// fit renders text in style, wrapping it when it would be wider than width
+func fit(style lipgloss.Style, text string, width int) string {
+	rendered := style.Render(text)
+	if width <= 0 || lipgloss.Width(rendered) <= width {
+		return rendered
+	}
+	frame := style.GetHorizontalFrameSize() - style.GetHorizontalPadding()
+	return style.Width(max(width-frame, 1)).Render(text)
+}
+
+// renderContent draws whatever is shown between the header and the footer
+func (m Model) renderContent() string {
+	var content string
+	if m.showHelp {
+		content = m.renderHelp()
+	} else if m.export != nil {
+		content = m.renderExport()
+	} else if p, ok := m.topPane(); ok {
+		content = m.renderPane(p)
+	} else {
+		content = m.renderActiveView()
+	}
+
+	if !m.watching() {
+		return content
+	}
+	if m.width >= sidePanelMinWidth || m.width == 0 {
+		return lipgloss.JoinHorizontal(lipgloss.Top, content, m.renderChangelog())
+	}
+	return content + "\n\n" + m.renderChangelog()
+}
+
+// contentViewport lays the content out in the room left by the header and
+// footer, keeping the current scroll position
+func (m Model) contentViewport() viewport.Model {
+	vp := m.viewport
+	content := m.renderContent()
+
+	if m.width == 0 || m.height == 0 {
+		// Before the first size message everything is shown unscrolled
+		vp.Width = lipgloss.Width(content)
+		vp.Height = lipgloss.Height(content)
+	} else {
+		// The header and footer are separated from the content by a blank line each
+		vp.Width = m.width
+		vp.Height = max(m.height-lipgloss.Height(m.renderHeader())-m.footerHeight()-2, 3)
+	}
+
+	vp.SetContent(content)
+	return vp
+}
+
+// scroll moves the content half a screen up or down
+func (m Model) scroll(down bool) Model {
+	vp := m.contentViewport()
+	if down {
+		vp.HalfPageDown()
+	} else {
+		vp.HalfPageUp()
+	}
+	m.viewport = vp
+	return m
+}
+
+// resetScroll returns to the top after the content was replaced
+func (m Model) resetScroll() Model {
+	m.viewport.GotoTop()
+	return m
+}
+
+// renderScrollPosition shows how far the content is scrolled when it does not fit
+func renderScrollPosition(vp viewport.Model) string {
+	if vp.TotalLineCount() <= vp.Height {
+		return ""
+	}
+	return fmt.Sprintf("%3.0f%%", vp.ScrollPercent()*100)
+}
+
+// renderDashboard shows the overview, languages and ranking side by side
+func (m Model) renderDashboard(width int) string {
+	gap := lipgloss.NewStyle().MarginLeft(2)
+
+	overview := m.renderOverviewView(width / 2)
+	rest := (width - lipgloss.Width(overview)) / 2
+
+	heading := m.theme.heading().MarginBottom(1)
+	languages := heading.Render("Languages") + "\n" +
+		m.renderLanguageBars(-1, rest-2)
+	ranking := heading.Render("Ranking") + "\n" +
+		fit(lipgloss.NewStyle(), m.renderRankingSummary(), rest-2)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		overview,
+		gap.Render(languages),
+		gap.Render(ranking))
+}
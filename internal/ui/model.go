@@ -1,15 +1,24 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github-profiler/internal/charts"
+	"github-profiler/internal/history"
 	"github-profiler/internal/models"
-	"github-profiler/internal/services"
+	"github-profiler/pkg/profiler"
 )
 
 // AppState represents the different states of the application
@@ -27,22 +36,51 @@ type Model struct {
 	// Application state
 	state    AppState
 	username string
-	token    string
 	format   string
 
 	// UI components
-	spinner spinner.Model
-	profile *models.UserProfile
-	error   error
-	width   int
-	height  int
+	input    textinput.Model
+	spinner  spinner.Model
+	viewport viewport.Model
+	keys     keyMap
+	help     help.Model
+	theme    Theme
+	repos    repoList
+	profile  *models.UserProfile
+	history  []history.Snapshot
+	error    error
+	width    int
+	height   int
 
 	// Services
-	githubService *services.GitHubService
+	client *profiler.Client
 
 	// Navigation
-	activeView ViewType
-	views      []ViewType
+	activeView      ViewType
+	views           []ViewType
+	showExplanation bool
+	panes           []pane
+	langCursor      int
+	export          *exportDialog
+	showHelp        bool
+
+	// Username prompt completion
+	recent        []string
+	searchResults []string
+	searchID      int
+
+	// Footer status message
+	status      string
+	statusError bool
+	statusID    int
+
+	// Watch mode
+	watchInterval   time.Duration
+	watchGeneration int
+	refreshing      bool
+	lastRefresh     time.Time
+	changed         map[string]bool
+	changelog       []changelogEntry
 }
 
 // ViewType represents different profile views
@@ -74,31 +112,85 @@ func GetViews() []ViewInfo {
 	}
 }
 
-// NewModel creates a new application model
-func NewModel(username, token, format string) Model {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-
-	githubService := services.NewGitHubService(token)
-
+// NewModel creates a new application model that fetches profiles with client.
+// The Ranking view expands into the score explanation when client records it.
+func NewModel(username, format string, client *profiler.Client) Model {
 	state := StateInput
 	if username != "" {
 		state = StateLoading
 	}
 
+	keys := defaultKeyMap()
+
+	return Model{
+		state:      state,
+		username:   username,
+		format:     format,
+		input:      newUsernameInput(username),
+		spinner:    newSpinner(Themes["dark"]),
+		keys:       keys,
+		help:       newHelp(Themes["dark"]),
+		theme:      Themes["dark"],
+		repos:      newRepoList(nil).setKeys(keys),
+		client:     client,
+		activeView: ViewOverview,
+		views:      []ViewType{ViewOverview, ViewRepositories, ViewLanguages, ViewActivity, ViewRanking},
+	}
+}
+
+// title names the tab of this model
+func (m Model) title() string {
+	switch {
+	case m.profile != nil:
+		return m.profile.User.Login
+	case m.username != "" && m.state != StateInput:
+		return m.username
+	default:
+		return "new"
+	}
+}
+
+// capturesText reports whether key presses are being typed into an input
+func (m Model) capturesText() bool {
+	return m.state == StateInput ||
+		m.repos.filtering ||
+		(m.export != nil && m.export.naming)
+}
+
+// fresh returns a model on the username prompt that shares the service,
+// key bindings and settings of m but none of its profile state
+func (m Model) fresh() Model {
 	return Model{
-		state:         state,
-		username:      username,
-		token:         token,
-		format:        format,
-		spinner:       s,
-		githubService: githubService,
+		state:         StateInput,
+		format:        m.format,
+		input:         newUsernameInput(""),
+		spinner:       newSpinner(m.theme),
+		keys:          m.keys,
+		help:          m.help,
+		theme:         m.theme,
+		repos:         newRepoList(nil).setKeys(m.keys).setTheme(m.theme),
+		width:         m.width,
+		height:        m.height,
+		client:        m.client,
 		activeView:    ViewOverview,
-		views:         []ViewType{ViewOverview, ViewRepositories, ViewLanguages, ViewActivity, ViewRanking},
+		views:         m.views,
+		watchInterval: m.watchInterval,
 	}
 }
 
+func newHelp(theme Theme) help.Model {
+	h := help.New()
+	h.Styles = theme.helpStyles()
+	return h
+}
+
+func newSpinner(theme Theme) spinner.Model {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(theme.Spinner)
+	return s
+}
+
 // Init implements the bubbletea.Model interface
 func (m Model) Init() tea.Cmd {
 	if m.state == StateLoading {
@@ -107,7 +199,11 @@ func (m Model) Init() tea.Cmd {
 			m.fetchProfile,
 		)
 	}
-	return m.spinner.Tick
+	return tea.Batch(
+		m.spinner.Tick,
+		textinput.Blink,
+		loadRecent,
+	)
 }
 
 // Update implements the bubbletea.Model interface
@@ -116,73 +212,339 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.repos = m.repos.setWidth(m.contentWidth())
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
-
-		case "enter":
-			if m.state == StateInput && m.username != "" {
-				m.state = StateLoading
-				return m, tea.Batch(
-					m.spinner.Tick,
-					m.fetchProfile,
-				)
-			}
+		return m.handleKey(msg)
 
-		case "left", "h":
-			if m.state == StateProfileView {
-				return m.previousView(), nil
-			}
-
-		case "right", "l":
-			if m.state == StateProfileView {
-				return m.nextView(), nil
-			}
+	case recentLoadedMsg:
+		m.recent = msg.logins
+		m.input.SetSuggestions(m.suggestions())
+		return m, nil
 
-		case "r":
-			if m.state == StateError || m.state == StateProfileView {
-				m.state = StateLoading
-				m.error = nil
-				return m, tea.Batch(
-					m.spinner.Tick,
-					m.fetchProfile,
-				)
-			}
+	case searchTickMsg:
+		if msg.id != m.searchID || m.state != StateInput {
+			return m, nil
+		}
+		return m, m.searchUsers(msg.query)
 
-		default:
-			if m.state == StateInput {
-				if msg.String() == "backspace" {
-					if len(m.username) > 0 {
-						m.username = m.username[:len(m.username)-1]
-					}
-				} else if len(msg.String()) == 1 {
-					m.username += msg.String()
-				}
-				return m, nil
-			}
+	case userSearchMsg:
+		// Results for an outdated query or a failed search leave the history suggestions alone
+		if msg.err != nil || msg.query != strings.TrimSpace(m.input.Value()) {
+			return m, nil
 		}
+		m.searchResults = msg.logins
+		m.input.SetSuggestions(m.suggestions())
+		return m, nil
 
 	case ProfileFetchedMsg:
 		m.profile = msg.Profile
+		m.history = msg.History
+		m.repos = m.repos.setRepositories(msg.Profile.Repositories)
+		m.panes = nil
+		m.export = nil
+		m.langCursor = 0
 		m.state = StateProfileView
-		return m, nil
+		m.lastRefresh = time.Now()
+		m.changed = nil
+		return m.scheduleWatch()
 
 	case ProfileErrorMsg:
 		m.error = msg.Error
 		m.state = StateError
+		return m.scheduleWatch()
+
+	case watchTickMsg:
+		if msg.generation != m.watchGeneration || m.refreshing {
+			return m, nil
+		}
+		switch m.state {
+		case StateProfileView:
+			m.refreshing = true
+			return m, tea.Batch(
+				m.spinner.Tick,
+				m.refreshProfile,
+			)
+		case StateError:
+			m.state = StateLoading
+			m.error = nil
+			return m, tea.Batch(
+				m.spinner.Tick,
+				m.fetchProfile,
+			)
+		}
+
+	case ProfileRefreshedMsg:
+		m.refreshing = false
+		if m.state != StateProfileView {
+			return m, nil
+		}
+		var changed bool
+		m, changed = m.applyRefresh(msg.Profile)
+		m.repos = m.repos.setRepositories(msg.Profile.Repositories)
+		var save tea.Cmd
+		if changed {
+			save = m.saveSnapshot(msg.Profile)
+		}
+		var next tea.Cmd
+		m, next = m.scheduleWatch()
+		return m, tea.Batch(save, next)
+
+	case RefreshFailedMsg:
+		m.refreshing = false
+		m = m.logChange(time.Now(), "refresh failed: "+msg.Error.Error())
+		return m.scheduleWatch()
+
+	case openResultMsg:
+		text, isError := describeOpenResult(msg)
+		return m.setStatus(text, isError)
+
+	case exportedMsg:
+		text, isError := describeExport(msg)
+		return m.setStatus(text, isError)
+
+	case clearStatusMsg:
+		if msg.id == m.statusID {
+			m.status = ""
+		}
+		return m, nil
+
+	case RepoDetailFetchedMsg:
+		return m.updatePane(msg.Detail.Repository, msg.Detail, nil), nil
+
+	case RepoDetailErrorMsg:
+		return m.updatePane(msg.Repo, nil, msg.Error), nil
+
+	case HistoryLoadedMsg:
+		if msg.History != nil {
+			m.history = msg.History
+		}
 		return m, nil
 
 	case spinner.TickMsg:
-		if m.state == StateLoading {
+		if m.state == StateLoading || m.refreshing || m.paneLoading() {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
 		}
 	}
 
+	// Keep the text cursors blinking while an input is focused
+	if m.state == StateInput {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	if m.export != nil && m.export.naming {
+		dialog := *m.export
+		var cmd tea.Cmd
+		dialog.filename, cmd = dialog.filename.Update(msg)
+		m.export = &dialog
+		return m, cmd
+	}
+	if m.repos.filtering {
+		var cmd tea.Cmd
+		m.repos.filter, cmd = m.repos.filter.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// WithTheme draws every view with the given theme
+func (m Model) WithTheme(theme Theme) Model {
+	m.theme = theme
+	m.spinner.Style = lipgloss.NewStyle().Foreground(theme.Spinner)
+	m.help = newHelp(theme)
+	m.repos = m.repos.setTheme(theme)
+	return m
+}
+
+// WithKeyMap applies key binding overrides by action name, e.g. from the config file
+func (m Model) WithKeyMap(overrides map[string][]string) (Model, error) {
+	keys, err := m.keys.override(overrides)
+	if err != nil {
+		return m, err
+	}
+	m.keys = keys
+	m.repos = m.repos.setKeys(keys)
+	return m, nil
+}
+
+// handleKey routes a key press to the innermost component that owns it
+func (m Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch m.state {
+	case StateInput:
+		// Every printable key belongs to the prompt, so only Ctrl+C quits here
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			username := strings.TrimSpace(m.input.Value())
+			if username == "" {
+				return m, nil
+			}
+			m.username = username
+			m.state = StateLoading
+			m.searchID++
+			return m, tea.Batch(
+				m.spinner.Tick,
+				m.fetchProfile,
+			)
+		}
+		return m.updateInput(msg)
+
+	case StateProfileView:
+		// Handled below
+
+	default:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Refresh) && m.state == StateError:
+			m.state = StateLoading
+			m.error = nil
+			return m, tea.Batch(
+				m.spinner.Tick,
+				m.fetchProfile,
+			)
+		}
+		return m, nil
+	}
+
+	// Focused text inputs get every key first, so editing keys such as ctrl+u
+	// are not taken for scrolling or shortcuts
+	if m.export != nil && m.export.naming {
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		return m.handleExportKey(msg)
+	}
+	if m.activeView == ViewRepositories && m.repos.filtering && msg.Type != tea.KeyCtrlC && !m.showHelp && m.export == nil && len(m.panes) == 0 {
+		var cmd tea.Cmd
+		m.repos, cmd, _ = m.repos.handleKey(msg, m.keys)
+		return m, cmd
+	}
+
+	// Long content scrolls the same way everywhere
+	switch {
+	case key.Matches(msg, m.keys.ScrollUp):
+		return m.scroll(false), nil
+	case key.Matches(msg, m.keys.ScrollDown):
+		return m.scroll(true), nil
+	}
+
+	// The help overlay and export menu sit above everything else while open
+	if m.showHelp {
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Help, m.keys.Back):
+			m.showHelp = false
+			m = m.resetScroll()
+		}
+		return m, nil
+	}
+	if m.export != nil {
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		return m.handleExportKey(msg)
+	}
+
+	// An open pane captures navigation until it is closed
+	if len(m.panes) > 0 {
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.popPane().resetScroll(), nil
+		case key.Matches(msg, m.keys.Open):
+			return m, openURL(m.selectedURL())
+		case key.Matches(msg, m.keys.Copy):
+			return m, copyURL(m.selectedURL())
+		case key.Matches(msg, m.keys.Export):
+			return m.openExport(), nil
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = true
+			m = m.resetScroll()
+		}
+		return m, nil
+	}
+
+	// The repository list gets first pick so typing in its filter never triggers shortcuts
+	if m.activeView == ViewRepositories && msg.Type != tea.KeyCtrlC {
+		var cmd tea.Cmd
+		var handled bool
+		m.repos, cmd, handled = m.repos.handleKey(msg, m.keys)
+		if handled {
+			return m, cmd
+		}
+	}
+
+	for i, b := range m.keys.viewBindings() {
+		if key.Matches(msg, b) && i < len(m.views) {
+			m.activeView = m.views[i]
+			return m.resetScroll(), nil
+		}
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Help):
+		m.showHelp = true
+		return m.resetScroll(), nil
+
+	case key.Matches(msg, m.keys.PrevView):
+		return m.previousView().resetScroll(), nil
+
+	case key.Matches(msg, m.keys.NextView):
+		return m.nextView().resetScroll(), nil
+
+	case key.Matches(msg, m.keys.Select):
+		if m.activeView == ViewRepositories {
+			if repo := m.repos.selected(); repo != nil {
+				return m.resetScroll().openRepository(repo)
+			}
+		}
+
+	case key.Matches(msg, m.keys.Up):
+		if m.activeView == ViewLanguages && m.langCursor > 0 {
+			m.langCursor--
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if m.activeView == ViewLanguages && m.langCursor < len(m.languageList())-1 {
+			m.langCursor++
+		}
+
+	case key.Matches(msg, m.keys.Open):
+		return m, openURL(m.selectedURL())
+
+	case key.Matches(msg, m.keys.Copy):
+		return m, copyURL(m.selectedURL())
+
+	case key.Matches(msg, m.keys.Export):
+		return m.openExport(), nil
+
+	case key.Matches(msg, m.keys.Explain):
+		if m.activeView == ViewRanking {
+			m.showExplanation = !m.showExplanation
+		}
+
+	case key.Matches(msg, m.keys.Refresh):
+		m.state = StateLoading
+		m.error = nil
+		return m, tea.Batch(
+			m.spinner.Tick,
+			m.fetchProfile,
+		)
+	}
+
 	return m, nil
 }
 
@@ -205,6 +567,7 @@ func (m Model) View() string {
 // Message types for Elm Architecture
 type ProfileFetchedMsg struct {
 	Profile *models.UserProfile
+	History []history.Snapshot
 }
 
 type ProfileErrorMsg struct {
@@ -217,15 +580,38 @@ func (m Model) fetchProfile() tea.Msg {
 	var err error
 
 	if m.username == "demo-user" {
-		profile, err = m.githubService.GetDemoProfile()
+		profile = m.client.DemoProfile()
 	} else {
-		profile, err = m.githubService.GetUserProfile(m.username)
+		profile, err = m.client.Profile(context.Background(), m.username)
 	}
 
 	if err != nil {
 		return ProfileErrorMsg{Error: err}
 	}
-	return ProfileFetchedMsg{Profile: profile}
+	m.rememberLogin(profile.User.Login)
+	return ProfileFetchedMsg{Profile: profile, History: m.recordSnapshot(profile)}
+}
+
+// recordSnapshot saves the profile to the history store and returns all known
+// snapshots for the user. History is best effort and never blocks the view.
+func (m Model) recordSnapshot(profile *models.UserProfile) []history.Snapshot {
+	if m.username == "demo-user" {
+		return nil
+	}
+
+	store, err := history.NewDefaultStore()
+	if err != nil {
+		return nil
+	}
+	if _, err := store.Save(profile); err != nil {
+		return nil
+	}
+
+	snapshots, err := store.List(profile.User.Login)
+	if err != nil {
+		return nil
+	}
+	return snapshots
 }
 
 // Navigation helpers
@@ -238,8 +624,8 @@ func (m Model) nextView() Model {
 		}
 	}
 
-	if currentIndex >= 0 && currentIndex < len(m.views)-1 {
-		m.activeView = m.views[currentIndex+1]
+	if currentIndex >= 0 {
+		m.activeView = m.views[(currentIndex+1)%len(m.views)]
 	}
 
 	return m
@@ -254,8 +640,8 @@ func (m Model) previousView() Model {
 		}
 	}
 
-	if currentIndex > 0 {
-		m.activeView = m.views[currentIndex-1]
+	if currentIndex >= 0 {
+		m.activeView = m.views[(currentIndex-1+len(m.views))%len(m.views)]
 	}
 
 	return m
@@ -263,14 +649,11 @@ func (m Model) previousView() Model {
 
 // Rendering methods
 func (m Model) renderInputView() string {
-	title := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("86")).
+	title := m.theme.heading().
 		MarginBottom(1).
 		Render("GitHub Profiler")
 
-	subtitle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+	subtitle := m.theme.muted().
 		Render("v1.0.0 - github@Tyeflu")
 
 	prompt := lipgloss.NewStyle().
@@ -281,12 +664,16 @@ func (m Model) renderInputView() string {
 	input := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		Padding(0, 1).
-		Render(m.username + "|")
+		Width(43).
+		Render(m.input.View())
+
+	if suggestions := m.renderSuggestions(); suggestions != "" {
+		input += "\n" + suggestions
+	}
 
-	instructions := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+	instructions := m.theme.muted().
 		MarginTop(1).
-		Render("Press Enter to analyze - Ctrl+C to quit")
+		Render("Press Enter to analyze - Tab to complete - ↑/↓ to choose - Ctrl+C to quit")
 
 	return fmt.Sprintf("%s\n%s\n%s\n%s\n%s", title, subtitle, prompt, input, instructions)
 }
@@ -298,20 +685,16 @@ func (m Model) renderLoadingView() string {
 }
 
 func (m Model) renderErrorView() string {
-	title := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("196")).
+	title := m.theme.errorText().Bold(true).
 		Render("ERROR")
 
-	errorMsg := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+	errorMsg := m.theme.muted().
 		MarginTop(1).
 		Render(m.error.Error())
 
-	instructions := lipgloss.NewStyle().
+	instructions := m.theme.muted().
 		MarginTop(2).
-		Foreground(lipgloss.Color("241")).
-		Render("Press 'r' to retry - Ctrl+C to quit")
+		Render(fmt.Sprintf("Press '%s' to retry - Ctrl+C to quit", m.keys.Refresh.Help().Key))
 
 	return fmt.Sprintf("%s\n%s\n%s", title, errorMsg, instructions)
 }
@@ -324,73 +707,134 @@ func (m Model) renderProfileView() string {
 	// Header with navigation
 	header := m.renderHeader()
 
-	// Active view content
-	var content string
+	// Active view content, or the pane opened on top of it, scrolled to fit
+	content := m.contentViewport()
+
+	// Footer with navigation instructions
+	footer := m.renderFooter(renderScrollPosition(content))
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, content.View(), footer)
+}
+
+// renderActiveView draws the content of the selected profile view
+func (m Model) renderActiveView() string {
 	switch m.activeView {
 	case ViewOverview:
-		content = m.renderOverviewView()
+		if width := m.contentWidth(); width >= dashboardMinWidth {
+			return m.renderDashboard(width)
+		}
+		return m.renderOverviewView(m.contentWidth())
 	case ViewRepositories:
-		content = m.renderRepositoriesView()
+		return m.renderRepositoriesView()
 	case ViewLanguages:
-		content = m.renderLanguagesView()
+		return m.renderLanguagesView()
 	case ViewActivity:
-		content = m.renderActivityView()
+		return m.renderActivityView()
 	case ViewRanking:
-		content = m.renderRankingView()
+		return m.renderRankingView()
+	default:
+		return ""
 	}
-
-	// Footer with navigation instructions
-	footer := m.renderFooter()
-
-	return fmt.Sprintf("%s\n\n%s\n\n%s", header, content, footer)
 }
 
 func (m Model) renderHeader() string {
 	user := m.profile.User
 
-	title := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("86")).
+	title := m.theme.heading().
 		Render("GitHub Profile Analysis")
 
-	subtitle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+	subtitle := m.theme.muted().
 		Render("v1.0.0 - github@Tyeflu")
 
 	userInfo := fmt.Sprintf("%s (%s)",
 		getStringValue(user.Name),
-		user.GetLogin())
+		user.Login)
 
-	// Navigation tabs
-	views := GetViews()
+	// Navigation tabs, shortened to their icons when the titles do not fit
+	navigation := m.renderNavigation(true)
+	if m.width > 0 && lipgloss.Width(navigation) > m.width {
+		navigation = m.renderNavigation(false)
+	}
+
+	if m.watching() {
+		subtitle += "\n" + m.renderWatchStatus()
+	}
+
+	return fmt.Sprintf("%s\n%s\n\n%s\n\n%s", title, subtitle, userInfo, navigation)
+}
+
+// renderNavigation draws the view tabs, with or without their titles
+func (m Model) renderNavigation(titles bool) string {
 	var tabs []string
-	for i, view := range views {
+	for i, view := range GetViews() {
 		viewType := ViewType(i)
-		style := lipgloss.NewStyle().Padding(0, 1)
-
+		style := m.theme.muted()
 		if viewType == m.activeView {
-			style = style.Background(lipgloss.Color("86")).Foreground(lipgloss.Color("0"))
-		} else {
-			style = style.Foreground(lipgloss.Color("241"))
+			style = m.theme.selected()
 		}
+		style = style.Padding(0, 1)
 
-		tabs = append(tabs, style.Render(fmt.Sprintf("%s %s", view.Icon, view.Title)))
+		label := view.Icon
+		if titles || viewType == m.activeView {
+			label = fmt.Sprintf("%s %s", view.Icon, view.Title)
+		}
+		tabs = append(tabs, style.Render(label))
 	}
 
-	navigation := lipgloss.JoinHorizontal(lipgloss.Left, tabs...)
+	return lipgloss.JoinHorizontal(lipgloss.Left, tabs...)
+}
 
-	return fmt.Sprintf("%s\n%s\n\n%s\n\n%s", title, subtitle, userInfo, navigation)
+// renderHelp draws every key binding for the ? overlay
+func (m Model) renderHelp() string {
+	title := m.theme.heading().
+		Render("Keyboard Shortcuts")
+
+	full := m.help
+	full.ShowAll = true
+
+	return m.theme.border().
+		Padding(1, 2).
+		Render(title + "\n\n" + full.View(m.keys))
 }
 
-func (m Model) renderFooter() string {
-	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Render("← → Navigate • r Refresh • q Quit")
+// footerHeight is the number of lines renderFooter takes
+func (m Model) footerHeight() int {
+	if m.status != "" {
+		return 2
+	}
+	return 1
+}
+
+func (m Model) renderFooter(scrollPosition string) string {
+	bindings := m.keys.ShortHelp()
+	switch {
+	case m.showHelp:
+		bindings = []key.Binding{m.keys.Help, m.keys.Quit}
+	case m.export != nil:
+		bindings = []key.Binding{m.keys.Back}
+	case len(m.panes) > 0:
+		bindings = []key.Binding{m.keys.Back, m.keys.Open, m.keys.Copy, m.keys.Export, m.keys.Help, m.keys.Quit}
+	}
+
+	footer := m.help.ShortHelpView(bindings)
+	if scrollPosition != "" {
+		prefix := m.theme.muted().Render(scrollPosition + "  ")
+		h := m.help
+		if h.Width > 0 {
+			h.Width -= lipgloss.Width(prefix)
+		}
+		footer = prefix + h.ShortHelpView(bindings)
+	}
+
+	if status := m.renderStatus(); status != "" {
+		footer = status + "\n" + footer
+	}
+	return footer
 }
 
 // View rendering methods for different sections
 
-func (m Model) renderOverviewView() string {
+func (m Model) renderOverviewView(width int) string {
 	user := m.profile.User
 	stats := m.profile.Stats
 
@@ -406,17 +850,17 @@ Company: %s
 Location: %s
 Website: %s
 Joined: %s
-Stats: Public Repos: %d | Followers: %d | Following: %d`,
+Stats: Public Repos: %s | Followers: %s | Following: %d`,
 		getStringValue(user.Name),
-		user.GetLogin(),
+		user.Login,
 		getStringValue(user.Bio),
 		getStringValue(user.Company),
 		getStringValue(user.Location),
 		getStringValue(user.Blog),
-		user.GetCreatedAt().Format("January 2006"),
-		user.GetPublicRepos(),
-		user.GetFollowers(),
-		user.GetFollowing())
+		user.CreatedAt.Format("January 2006"),
+		m.highlight("repos", fmt.Sprint(user.PublicRepos)),
+		m.highlight("followers", fmt.Sprint(user.Followers)),
+		user.Following)
 
 	// Quick stats
 	statsBox := lipgloss.NewStyle().
@@ -424,103 +868,102 @@ Stats: Public Repos: %d | Followers: %d | Following: %d`,
 		Padding(1).
 		MarginTop(1)
 
-	quickStats := fmt.Sprintf(`Total Stars: %d
-Total Forks: %d
+	quickStats := fmt.Sprintf(`Total Stars: %s
+Total Forks: %s
 Repository Size: %.1f MB
 Avg Stars/Repo: %.1f`,
-		stats.TotalStars,
-		stats.TotalForks,
+		m.highlight("stars", fmt.Sprint(stats.TotalStars)),
+		m.highlight("forks", fmt.Sprint(stats.TotalForks)),
 		float64(stats.TotalSize)/1024,
 		stats.AvgStarsPerRepo)
 
-	return userBox.Render(userInfo) + "\n" + statsBox.Render(quickStats)
+	view := fit(userBox, userInfo, width) + "\n" + fit(statsBox, quickStats, width)
+	if trends := m.renderTrends(width); trends != "" {
+		view += "\n" + trends
+	}
+	return view
 }
 
-func (m Model) renderRepositoriesView() string {
-	repos := m.profile.Repositories
-
-	if len(repos) == 0 {
-		return "No repositories found"
+// renderTrends draws sparklines for the headline metrics across saved snapshots
+func (m Model) renderTrends(width int) string {
+	if len(m.history) < 2 {
+		return ""
 	}
 
-	// Sort repos by stars
-	topRepos := repos
-	if len(repos) > 8 {
-		topRepos = repos[:8]
+	var lines []string
+	for _, series := range history.Trends(m.history) {
+		lines = append(lines, fmt.Sprintf("%-10s %s  %.1f (%+.1f)",
+			series.Name,
+			charts.Sparkline(series.Values),
+			series.Latest(),
+			series.Change()))
 	}
 
-	var repoList []string
-	for i, repo := range topRepos {
-		if repo.GetFork() {
-			continue
-		}
+	title := fmt.Sprintf("Trends since %s (%d snapshots)",
+		m.history[0].TakenAt.Format("Jan 2, 2006"),
+		len(m.history))
 
-		repoInfo := fmt.Sprintf(`%d. %s
-   %s
-   Stars: %d  Forks: %d  Language: %s
-   Updated: %s`,
-			i+1,
-			repo.GetName(),
-			getStringValue(repo.Description),
-			repo.GetStargazersCount(),
-			repo.GetForksCount(),
-			getStringValue(repo.Language),
-			repo.GetUpdatedAt().Format("Jan 2006"))
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(1).
+		MarginTop(1)
+	return fit(box, title+"\n\n"+strings.Join(lines, "\n"), width)
+}
 
-		repoList = append(repoList, repoInfo)
+func (m Model) renderRepositoriesView() string {
+	if len(m.profile.Repositories) == 0 {
+		return "No repositories found"
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, repoList...)
+	return m.repos.View(m.keys, m.help)
 }
 
 func (m Model) renderLanguagesView() string {
-	languages := m.profile.Languages
+	return m.renderLanguageBars(m.langCursor, m.contentWidth())
+}
 
-	if len(languages.Languages) == 0 {
+// renderLanguageBars draws a bar per language scaled to width, marking the
+// language at cursor; a negative cursor marks none
+func (m Model) renderLanguageBars(cursor, width int) string {
+	langList := m.languageList()
+
+	if len(langList) == 0 {
 		return "No language data available"
 	}
 
-	// Convert to sorted slice
-	type langItem struct {
-		name       string
-		percentage float64
-		repos      int
+	// Name, percentage and repo count take about 36 columns next to the bar
+	barWidth := 20
+	if width > 0 {
+		barWidth = min(max(width-36, 5), 40)
 	}
 
-	var langList []langItem
-	for _, lang := range languages.Languages {
-		langList = append(langList, langItem{
-			name:       lang.Name,
-			percentage: lang.Percentage,
-			repos:      lang.RepoCount,
-		})
+	// The stacked bar spans the name, bar and percentage columns
+	segments := make([]charts.Segment, len(langList))
+	for i, lang := range langList {
+		segments[i] = charts.Segment{Value: lang.Percentage, Color: m.languageColor(lang.Name)}
 	}
-
-	// Sort by percentage (simple bubble sort for now)
-	for i := 0; i < len(langList)-1; i++ {
-		for j := 0; j < len(langList)-i-1; j++ {
-			if langList[j].percentage < langList[j+1].percentage {
-				langList[j], langList[j+1] = langList[j+1], langList[j]
-			}
-		}
+	langDisplay := []string{
+		"  " + charts.StackedBar(segments, barWidth+20, m.theme.Monochrome),
+		"",
 	}
 
-	var langDisplay []string
-	for _, lang := range langList {
-		if lang.percentage < 1.0 {
-			continue // Skip languages with less than 1%
-		}
-
-		// Create progress bar
-		barWidth := 20
-		fillWidth := int((lang.percentage / 100.0) * float64(barWidth))
-		bar := strings.Repeat("█", fillWidth) + strings.Repeat("░", barWidth-fillWidth)
+	for i, lang := range langList {
+		bar := lipgloss.NewStyle().
+			Foreground(m.languageColor(lang.Name)).
+			Render(charts.Bar(lang.Percentage, 100, barWidth))
 
 		langInfo := fmt.Sprintf("%-12s %s %.1f%% (%d repos)",
-			lang.name,
+			lang.Name,
 			bar,
-			lang.percentage,
-			lang.repos)
+			lang.Percentage,
+			lang.RepoCount)
+
+		if i == cursor {
+			langInfo = m.theme.heading().
+				Render("> " + langInfo)
+		} else {
+			langInfo = "  " + langInfo
+		}
 
 		langDisplay = append(langDisplay, langInfo)
 	}
@@ -528,6 +971,43 @@ func (m Model) renderLanguagesView() string {
 	return lipgloss.JoinVertical(lipgloss.Left, langDisplay...)
 }
 
+// languageColor returns the linguist colour of a language, or no colour for monochrome themes
+func (m Model) languageColor(name string) lipgloss.TerminalColor {
+	if m.theme.Monochrome {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(charts.LanguageColor(name))
+}
+
+// languageList returns the languages with at least 1% share, largest first
+func (m Model) languageList() []models.LanguageInfo {
+	var langList []models.LanguageInfo
+	for _, lang := range m.profile.Languages.Languages {
+		if lang.Percentage < 1.0 {
+			continue // Skip languages with less than 1%
+		}
+		langList = append(langList, lang)
+	}
+
+	sort.Slice(langList, func(i, j int) bool {
+		if langList[i].Percentage != langList[j].Percentage {
+			return langList[i].Percentage > langList[j].Percentage
+		}
+		return langList[i].Name < langList[j].Name
+	})
+
+	return langList
+}
+
+// selectedLanguage returns the language under the cursor in the Languages view
+func (m Model) selectedLanguage() (string, bool) {
+	langList := m.languageList()
+	if m.langCursor < 0 || m.langCursor >= len(langList) {
+		return "", false
+	}
+	return langList[m.langCursor].Name, true
+}
+
 func (m Model) renderActivityView() string {
 	stats := m.profile.Stats
 	activity := m.profile.Activity
@@ -535,72 +1015,168 @@ func (m Model) renderActivityView() string {
 	activityInfo := fmt.Sprintf(`Contribution Score: %.1f
 Recent Commits: %d
 
-Repository Update Frequency:
-   Weekly: %d repositories
-   Monthly: %d repositories  
-   Quarterly: %d repositories
-   Yearly: %d repositories
-   Stale (>1 year): %d repositories
-
-Repository Timeline:`,
+Repository Update Frequency:`,
 		activity.ContributionScore,
-		activity.RecentCommits,
-		stats.UpdateFrequency["weekly"],
-		stats.UpdateFrequency["monthly"],
-		stats.UpdateFrequency["quarterly"],
-		stats.UpdateFrequency["yearly"],
-		stats.UpdateFrequency["stale"])
+		activity.RecentCommits)
+
+	// Update frequency bars share a scale so the buckets compare directly
+	buckets := []struct{ key, label string }{
+		{"weekly", "Weekly"},
+		{"monthly", "Monthly"},
+		{"quarterly", "Quarterly"},
+		{"yearly", "Yearly"},
+		{"stale", "Stale (>1 year)"},
+	}
+	busiest := 0
+	for _, bucket := range buckets {
+		busiest = max(busiest, stats.UpdateFrequency[bucket.key])
+	}
+	barWidth := min(max(m.contentWidth()-40, 5), 30)
+
+	var frequency []string
+	for _, bucket := range buckets {
+		count := stats.UpdateFrequency[bucket.key]
+		frequency = append(frequency, fmt.Sprintf("   %-16s %s %d repositories",
+			bucket.label,
+			m.theme.accent().Render(charts.Bar(float64(count), float64(busiest), barWidth)),
+			count))
+	}
 
-	// Add timeline
-	var timeline []string
-	for _, entry := range stats.CreationTimeline {
-		timeline = append(timeline, fmt.Sprintf("   %d: %d repositories", entry.Year, entry.Count))
+	view := activityInfo + "\n" + strings.Join(frequency, "\n") + "\n\nRepository Timeline:"
+	if len(stats.CreationTimeline) == 0 {
+		return view + "\n   No repositories"
 	}
 
-	return activityInfo + "\n" + lipgloss.JoinVertical(lipgloss.Left, timeline...)
+	// One column per year the user created repositories
+	values := make([]float64, len(stats.CreationTimeline))
+	labels := make([]string, len(stats.CreationTimeline))
+	for i, entry := range stats.CreationTimeline {
+		values[i] = float64(entry.Count)
+		labels[i] = fmt.Sprint(entry.Year)
+	}
+	columns := m.theme.accent().
+		MarginLeft(3).
+		Render(charts.Columns(values, labels, 6))
+
+	return view + "\n\n" + columns
 }
 
-func (m Model) renderRankingView() string {
+// renderRankingSummary draws the badge and score breakdown
+func (m Model) renderRankingSummary() string {
 	ranking := m.profile.Ranking
 
 	// Rank badge
-	badgeStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("86")).
-		Foreground(lipgloss.Color("0")).
+	badgeStyle := m.theme.selected().
 		Bold(true).
 		Padding(0, 1).
 		MarginBottom(1)
 
 	badge := badgeStyle.Render(fmt.Sprintf("BADGE: %s", ranking.Badge))
+	if m.changed["rank"] {
+		badge = m.theme.highlighted().Bold(true).Padding(0, 1).MarginBottom(1).Render(fmt.Sprintf("BADGE: %s", ranking.Badge))
+	}
 
 	// Score breakdown
+	limits := ranking.MaxScores
 	scoreInfo := fmt.Sprintf(`Overall Rank: %s
-Total Score: %.1f/100 (%.1f%% percentile)
+Total Score: %s/%.0f (%s)
+Scoring Model: %s
 
 Score Breakdown:
-Social Score:     %.1f/25  (%.1f%%)
-Code Score:       %.1f/30  (%.1f%%)
-Activity Score:   %.1f/25  (%.1f%%)
-Innovation Score: %.1f/20  (%.1f%%)`,
+Social Score:     %.1f/%-4.0f %s (%.1f%%)
+Code Score:       %.1f/%-4.0f %s (%.1f%%)
+Activity Score:   %.1f/%-4.0f %s (%.1f%%)
+Innovation Score: %.1f/%-4.0f %s (%.1f%%)`,
 		ranking.OverallRank,
-		ranking.TotalScore,
-		ranking.Percentile,
+		m.highlight("score", fmt.Sprintf("%.1f", ranking.TotalScore)),
+		limits.Total,
+		formatPercentile(ranking),
+		ranking.ScoringModel,
 		ranking.SocialScore,
-		(ranking.SocialScore/25)*100,
+		limits.Social,
+		m.scoreBar(ranking.SocialScore, limits.Social),
+		ratio(ranking.SocialScore, limits.Social),
 		ranking.CodeScore,
-		(ranking.CodeScore/30)*100,
+		limits.Code,
+		m.scoreBar(ranking.CodeScore, limits.Code),
+		ratio(ranking.CodeScore, limits.Code),
 		ranking.ActivityScore,
-		(ranking.ActivityScore/25)*100,
+		limits.Activity,
+		m.scoreBar(ranking.ActivityScore, limits.Activity),
+		ratio(ranking.ActivityScore, limits.Activity),
 		ranking.InnovationScore,
-		(ranking.InnovationScore/20)*100)
+		limits.Innovation,
+		m.scoreBar(ranking.InnovationScore, limits.Innovation),
+		ratio(ranking.InnovationScore, limits.Innovation))
 
 	return badge + "\n\n" + scoreInfo
 }
 
-// Helper function to safely get string values
-func getStringValue(s *string) string {
-	if s == nil {
+// scoreBar draws a score against its maximum for the ranking breakdown
+func (m Model) scoreBar(score, limit float64) string {
+	return m.theme.accent().
+		Render(charts.Bar(score, limit, 20))
+}
+
+func (m Model) renderRankingView() string {
+	ranking := m.profile.Ranking
+	view := m.renderRankingSummary()
+	if ranking.Explanation == nil {
+		return view
+	}
+
+	toggle := m.theme.muted().
+		MarginTop(1)
+	if !m.showExplanation {
+		return view + "\n" + toggle.Render(fmt.Sprintf("Press '%s' to explain this score", m.keys.Explain.Help().Key))
+	}
+	return view + "\n" + toggle.Render(fmt.Sprintf("Press '%s' to hide the explanation", m.keys.Explain.Help().Key)) + "\n" + m.renderExplanation(ranking.Explanation)
+}
+
+// renderExplanation lists every scoring rule that fired and how to raise the score
+func (m Model) renderExplanation(explanation *models.RankingExplanation) string {
+	heading := m.theme.heading()
+
+	var lines []string
+	lines = append(lines, heading.Render("How this score was made:"))
+	for _, rule := range explanation.Rules {
+		lines = append(lines, "   "+rule.Description)
+	}
+
+	if len(explanation.Hints) > 0 {
+		lines = append(lines, "", heading.Render("What would raise this score:"))
+		for _, hint := range explanation.Hints {
+			lines = append(lines, "   "+hint.Message)
+		}
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1).
+		MarginTop(1)
+	return fit(box, strings.Join(lines, "\n"), m.contentWidth())
+}
+
+// formatPercentile describes where the score sits in the reference distribution
+func formatPercentile(ranking models.RankingInfo) string {
+	if ranking.Percentile == nil {
+		return "percentile n/a without a matching reference distribution"
+	}
+	return fmt.Sprintf("%.1f percentile, reference %s", *ranking.Percentile, ranking.PercentileSource)
+}
+
+// ratio returns score as a percentage of limit
+func ratio(score, limit float64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return (score / limit) * 100
+}
+
+// getStringValue returns s, or a placeholder when it is empty
+func getStringValue(s string) string {
+	if s == "" {
 		return "Not specified"
 	}
-	return *s
+	return s
 }
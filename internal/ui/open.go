@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github-profiler/internal/browser"
+	"github-profiler/internal/models"
+)
+
+// openResultMsg reports how a URL was handed to the user
+type openResultMsg struct {
+	url    string
+	copied bool
+	err    error
+}
+
+// terminal is the output the program draws on. Each frame is a single write,
+// so serialising writes keeps the clipboard sequence from landing inside one.
+var terminal = &lockedWriter{w: os.Stdout}
+
+// WithTerminal draws the program on the output clipboard commands write to
+func WithTerminal() tea.ProgramOption {
+	return tea.WithOutput(terminal)
+}
+
+// lockedWriter serialises writes to w. It exposes the file so Bubble Tea
+// still sees a terminal.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  *os.File
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+func (l *lockedWriter) Read(p []byte) (int, error) { return l.w.Read(p) }
+func (l *lockedWriter) Close() error               { return l.w.Close() }
+func (l *lockedWriter) Fd() uintptr                { return l.w.Fd() }
+
+// selectedURL returns the URL of whatever is currently selected: the open
+// repository pane, the highlighted repository, a language search or the user
+func (m Model) selectedURL() string {
+	if m.profile == nil {
+		return ""
+	}
+	login := m.profile.User.Login
+
+	if p, ok := m.topPane(); ok && p.kind == paneRepositoryDetail {
+		return repositoryURL(p.repo, login)
+	}
+
+	switch m.activeView {
+	case ViewRepositories:
+		if repo := m.repos.selected(); repo != nil {
+			return repositoryURL(repo, login)
+		}
+	case ViewLanguages:
+		if lang, ok := m.selectedLanguage(); ok {
+			return languageSearchURL(login, lang)
+		}
+	}
+
+	return profileURL(m.profile.User)
+}
+
+// openURL opens url in the browser, falling back to the clipboard
+func openURL(target string) tea.Cmd {
+	return func() tea.Msg {
+		err := browser.Open(target)
+		if err == nil {
+			return openResultMsg{url: target}
+		}
+
+		if copyErr := browser.Copy(terminal, target); copyErr != nil {
+			return openResultMsg{url: target, err: errors.Join(err, copyErr)}
+		}
+		return openResultMsg{url: target, copied: true}
+	}
+}
+
+// copyURL places url on the clipboard
+func copyURL(target string) tea.Cmd {
+	return func() tea.Msg {
+		if err := browser.Copy(terminal, target); err != nil {
+			return openResultMsg{url: target, err: err}
+		}
+		return openResultMsg{url: target, copied: true}
+	}
+}
+
+// describeOpenResult turns an open result into a status message
+func describeOpenResult(msg openResultMsg) (string, bool) {
+	switch {
+	case msg.err != nil:
+		return fmt.Sprintf("Could not open %s: %v", msg.url, msg.err), true
+	case msg.copied:
+		// The terminal never confirms an OSC52 request
+		return "Sent to clipboard: " + msg.url, false
+	default:
+		return "Opened in browser: " + msg.url, false
+	}
+}
+
+func profileURL(user models.User) string {
+	if user.HTMLURL != "" {
+		return user.HTMLURL
+	}
+	return "https://github.com/" + user.Login
+}
+
+func repositoryURL(repo *models.Repository, owner string) string {
+	if repo.HTMLURL != "" {
+		return repo.HTMLURL
+	}
+	if repo.FullName != "" {
+		return "https://github.com/" + repo.FullName
+	}
+	return "https://github.com/" + owner + "/" + repo.Name
+}
+
+// languageSearchURL searches the user's repositories written in lang
+func languageSearchURL(login, lang string) string {
+	if strings.Contains(lang, " ") {
+		lang = `"` + lang + `"`
+	}
+	query := url.Values{
+		"q":    {fmt.Sprintf("user:%s language:%s", login, lang)},
+		"type": {"repositories"},
+	}
+	return "https://github.com/search?" + query.Encode()
+}
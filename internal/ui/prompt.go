@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github-profiler/internal/history"
+)
+
+// searchDebounce is how long typing must pause before GitHub is searched
+const searchDebounce = 300 * time.Millisecond
+
+// minSearchLength is the shortest prefix worth a user search
+const minSearchLength = 2
+
+// searchLimit bounds the users requested per search
+const searchLimit = 10
+
+// maxShownSuggestions bounds the suggestion list under the prompt
+const maxShownSuggestions = 8
+
+// Username prompt messages
+type recentLoadedMsg struct {
+	logins []string
+}
+
+type searchTickMsg struct {
+	id    int
+	query string
+}
+
+type userSearchMsg struct {
+	query  string
+	logins []string
+	err    error
+}
+
+// newUsernameInput creates the username prompt with completion enabled
+func newUsernameInput(username string) textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "octocat"
+	input.Prompt = ""
+	input.CharLimit = 39 // the longest login GitHub allows
+	input.ShowSuggestions = true
+	input.SetValue(username)
+	input.Focus()
+	return input
+}
+
+// loadRecent is a command that reads the previously profiled logins
+func loadRecent() tea.Msg {
+	recent, err := history.NewDefaultRecent()
+	if err != nil {
+		return recentLoadedMsg{}
+	}
+	logins, _ := recent.Load()
+	return recentLoadedMsg{logins: logins}
+}
+
+// rememberLogin adds a profiled login to the prompt history. History is best
+// effort and never blocks the view.
+func (m Model) rememberLogin(login string) {
+	if m.username == "demo-user" {
+		return
+	}
+	if recent, err := history.NewDefaultRecent(); err == nil {
+		_ = recent.Add(login)
+	}
+}
+
+// updateInput passes a key to the prompt and schedules a search when the text changed
+func (m Model) updateInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	before := m.input.Value()
+
+	// Completing takes the login exactly as GitHub spells it
+	var cmd tea.Cmd
+	if msg.Type == tea.KeyTab && len(m.input.MatchedSuggestions()) > 0 {
+		m.input.SetValue(m.input.CurrentSuggestion())
+		m.input.CursorEnd()
+	} else {
+		m.input, cmd = m.input.Update(msg)
+	}
+
+	query := strings.TrimSpace(m.input.Value())
+	if query == strings.TrimSpace(before) {
+		return m, cmd
+	}
+
+	m.searchResults = nil
+	m.input.SetSuggestions(m.suggestions())
+	if len(query) < minSearchLength {
+		return m, cmd
+	}
+
+	// Only the last keystroke of a burst leads to a search
+	m.searchID++
+	id := m.searchID
+	return m, tea.Batch(cmd, tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchTickMsg{id: id, query: query}
+	}))
+}
+
+// searchUsers is a command that asks GitHub for logins starting with query
+func (m Model) searchUsers(query string) tea.Cmd {
+	return func() tea.Msg {
+		logins, err := m.client.SearchUsers(context.Background(), query, searchLimit)
+		return userSearchMsg{query: query, logins: logins, err: err}
+	}
+}
+
+// suggestions merges the login history with the latest search results
+func (m Model) suggestions() []string {
+	seen := make(map[string]bool)
+	var merged []string
+	for _, login := range append(append([]string(nil), m.recent...), m.searchResults...) {
+		folded := strings.ToLower(login)
+		if !seen[folded] {
+			seen[folded] = true
+			merged = append(merged, login)
+		}
+	}
+	return merged
+}
+
+// isRecent reports whether login was profiled before
+func (m Model) isRecent(login string) bool {
+	for _, recent := range m.recent {
+		if strings.EqualFold(recent, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderSuggestions lists the completions matching the prompt
+func (m Model) renderSuggestions() string {
+	matches := m.input.MatchedSuggestions()
+	if len(matches) == 0 {
+		return ""
+	}
+
+	muted := m.theme.muted()
+	selected := m.theme.selected()
+
+	current := m.input.CurrentSuggestionIndex()
+	start := 0
+	if current >= maxShownSuggestions {
+		start = current - maxShownSuggestions + 1
+	}
+	end := min(start+maxShownSuggestions, len(matches))
+
+	var lines []string
+	for i := start; i < end; i++ {
+		line := " " + matches[i] + " "
+		if i == current {
+			line = selected.Render(line)
+		}
+		if m.isRecent(matches[i]) {
+			line += muted.Render(" recent")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
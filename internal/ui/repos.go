@@ -0,0 +1,385 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github-profiler/internal/models"
+)
+
+// repoSortKey selects the column the repository list is ordered by
+type repoSortKey int
+
+const (
+	sortByStars repoSortKey = iota
+	sortByForks
+	sortBySize
+	sortByUpdated
+	sortByCreated
+)
+
+// repoSortNames are the labels of the sort keys, in cycling order
+var repoSortNames = []string{"stars", "forks", "size", "updated", "created"}
+
+// repoListHeight is the number of repository rows shown at once
+const repoListHeight = 12
+
+// repoList is a scrollable, sortable and filterable table of repositories
+type repoList struct {
+	table     table.Model
+	filter    textinput.Model
+	filtering bool
+
+	sortKey      repoSortKey
+	showForks    bool
+	showArchived bool
+	showPrivate  bool
+
+	repos   []*models.Repository
+	visible []*models.Repository
+
+	theme Theme
+	width int
+}
+
+// newRepoList creates a repository list showing every original repository by stars
+func newRepoList(repos []*models.Repository) repoList {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter repositories"
+
+	// The default page keys include f and b, which the list uses for toggles
+	keys := table.DefaultKeyMap()
+	keys.PageUp = key.NewBinding(key.WithKeys("pgup"))
+	keys.PageDown = key.NewBinding(key.WithKeys("pgdown"))
+
+	l := repoList{
+		table: table.New(
+			table.WithColumns(repoColumns()),
+			table.WithHeight(repoListHeight),
+			table.WithFocused(true),
+			table.WithKeyMap(keys),
+		),
+		filter:       filter,
+		showArchived: true,
+		showPrivate:  true,
+	}
+	return l.setTheme(Themes["dark"]).setRepositories(repos)
+}
+
+// setTheme restyles the table header and selected row
+func (l repoList) setTheme(theme Theme) repoList {
+	l.theme = theme
+
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		Bold(true)
+	styles.Selected = theme.selected().
+		Bold(false)
+	l.table.SetStyles(styles)
+	return l
+}
+
+func repoColumns() []table.Column {
+	return []table.Column{
+		{Title: "#", Width: 4},
+		{Title: "Name", Width: 28},
+		{Title: "Language", Width: 12},
+		{Title: "Stars", Width: 7},
+		{Title: "Forks", Width: 6},
+		{Title: "Size", Width: 9},
+		{Title: "Updated", Width: 9},
+		{Title: "Created", Width: 9},
+		{Title: "Flags", Width: 5},
+	}
+}
+
+// Column positions used when fitting the table to the terminal
+const (
+	repoColumnName    = 1
+	repoColumnForks   = 4
+	repoColumnSize    = 5
+	repoColumnUpdated = 6
+	repoColumnCreated = 7
+	repoColumnFlags   = 8
+)
+
+// repoColumnDropOrder lists the columns hidden first on narrow terminals
+var repoColumnDropOrder = []int{repoColumnCreated, repoColumnSize, repoColumnForks, repoColumnFlags, repoColumnUpdated}
+
+// setWidth fits the table columns to width, hiding the least useful columns
+// and giving spare room to the name. A width of 0 keeps the default layout.
+func (l repoList) setWidth(width int) repoList {
+	columns := repoColumns()
+
+	// Every cell is padded by one column on each side
+	total := func() int {
+		sum := 0
+		for _, column := range columns {
+			if column.Width > 0 {
+				sum += column.Width + 2
+			}
+		}
+		return sum
+	}
+
+	if width > 0 {
+		for _, i := range repoColumnDropOrder {
+			if total() <= width {
+				break
+			}
+			// A zero width hides the column without reshaping the rows
+			columns[i].Width = 0
+		}
+		name := &columns[repoColumnName]
+		name.Width = min(max(name.Width+width-total(), 12), 40)
+	}
+
+	l.table.SetColumns(columns)
+	l.width = width
+	return l
+}
+
+// setKeys moves the table cursor with the configured up and down keys
+func (l repoList) setKeys(keys keyMap) repoList {
+	l.table.KeyMap.LineUp = keys.Up
+	l.table.KeyMap.LineDown = keys.Down
+	return l
+}
+
+// setRepositories replaces the repositories while keeping sort, filter and toggles
+func (l repoList) setRepositories(repos []*models.Repository) repoList {
+	l.repos = repos
+	return l.refresh()
+}
+
+// selected returns the repository under the cursor
+func (l repoList) selected() *models.Repository {
+	cursor := l.table.Cursor()
+	if cursor < 0 || cursor >= len(l.visible) {
+		return nil
+	}
+	return l.visible[cursor]
+}
+
+// handleKey processes a key press and reports whether the list consumed it
+func (l repoList) handleKey(msg tea.KeyMsg, keys keyMap) (repoList, tea.Cmd, bool) {
+	if l.filtering {
+		switch msg.String() {
+		case "enter":
+			l.filtering = false
+			l.filter.Blur()
+			return l, nil, true
+		case "esc":
+			l.filtering = false
+			l.filter.Blur()
+			l.filter.SetValue("")
+			return l.refresh(), nil, true
+		}
+
+		var cmd tea.Cmd
+		l.filter, cmd = l.filter.Update(msg)
+		return l.refresh(), cmd, true
+	}
+
+	switch {
+	case key.Matches(msg, keys.Filter):
+		l.filtering = true
+		return l, l.filter.Focus(), true
+	case key.Matches(msg, keys.Back):
+		if l.filter.Value() == "" {
+			return l, nil, false
+		}
+		l.filter.SetValue("")
+		return l.refresh(), nil, true
+	case key.Matches(msg, keys.Sort):
+		l.sortKey = (l.sortKey + 1) % repoSortKey(len(repoSortNames))
+		return l.refresh(), nil, true
+	case key.Matches(msg, keys.Forks):
+		l.showForks = !l.showForks
+		return l.refresh(), nil, true
+	case key.Matches(msg, keys.Archived):
+		l.showArchived = !l.showArchived
+		return l.refresh(), nil, true
+	case key.Matches(msg, keys.Private):
+		l.showPrivate = !l.showPrivate
+		return l.refresh(), nil, true
+	}
+
+	var cmd tea.Cmd
+	before := l.table.Cursor()
+	l.table, cmd = l.table.Update(msg)
+	return l, cmd, l.table.Cursor() != before || cmd != nil
+}
+
+// refresh recomputes the visible repositories and table rows
+func (l repoList) refresh() repoList {
+	var candidates []*models.Repository
+	for _, repo := range l.repos {
+		if repo.Fork && !l.showForks {
+			continue
+		}
+		if repo.Archived && !l.showArchived {
+			continue
+		}
+		if repo.Private && !l.showPrivate {
+			continue
+		}
+		candidates = append(candidates, repo)
+	}
+
+	sortRepositories(candidates, l.sortKey)
+
+	// A filter lists the best matches first; the sort key breaks ties, since
+	// fuzzy.Find keeps the input order among equal scores
+	if pattern := strings.TrimSpace(l.filter.Value()); pattern != "" {
+		targets := make([]string, len(candidates))
+		for i, repo := range candidates {
+			targets[i] = repo.Name + " " + repo.Description
+		}
+
+		var matched []*models.Repository
+		for _, match := range fuzzy.Find(pattern, targets) {
+			matched = append(matched, candidates[match.Index])
+		}
+		candidates = matched
+	}
+
+	l.visible = candidates
+
+	rows := make([]table.Row, len(candidates))
+	for i, repo := range candidates {
+		rows[i] = table.Row{
+			fmt.Sprintf("%d", i+1),
+			repo.Name,
+			repo.Language,
+			fmt.Sprintf("%d", repo.StargazersCount),
+			fmt.Sprintf("%d", repo.ForksCount),
+			formatSize(repo.Size),
+			repo.UpdatedAt.Format("Jan 2006"),
+			repo.CreatedAt.Format("Jan 2006"),
+			repoFlags(repo),
+		}
+	}
+	l.table.SetRows(rows)
+	// Shrink short lists; the header and its border take two lines
+	l.table.SetHeight(min(len(rows), repoListHeight) + 2)
+	if l.table.Cursor() >= len(rows) {
+		l.table.SetCursor(len(rows) - 1)
+	}
+	if l.table.Cursor() < 0 && len(rows) > 0 {
+		l.table.SetCursor(0)
+	}
+
+	return l
+}
+
+// sortRepositories orders repositories by the key, largest or newest first
+func sortRepositories(repos []*models.Repository, by repoSortKey) {
+	less := func(a, b *models.Repository) bool {
+		switch by {
+		case sortByForks:
+			return a.ForksCount > b.ForksCount
+		case sortBySize:
+			return a.Size > b.Size
+		case sortByUpdated:
+			return a.UpdatedAt.After(b.UpdatedAt)
+		case sortByCreated:
+			return a.CreatedAt.After(b.CreatedAt)
+		default:
+			return a.StargazersCount > b.StargazersCount
+		}
+	}
+
+	sort.SliceStable(repos, func(i, j int) bool {
+		return less(repos[i], repos[j])
+	})
+}
+
+// repoFlags marks forks, archived and private repositories
+func repoFlags(repo *models.Repository) string {
+	var flags string
+	if repo.Fork {
+		flags += "F"
+	}
+	if repo.Archived {
+		flags += "A"
+	}
+	if repo.Private {
+		flags += "P"
+	}
+	return flags
+}
+
+// formatSize renders a repository size given in KB
+func formatSize(kb int) string {
+	if kb >= 1024 {
+		return fmt.Sprintf("%.1f MB", float64(kb)/1024)
+	}
+	return fmt.Sprintf("%d KB", kb)
+}
+
+// View renders the status line, table and the description of the selected repository
+func (l repoList) View(keys keyMap, help help.Model) string {
+	muted := l.theme.muted()
+
+	order := repoSortNames[l.sortKey]
+	if strings.TrimSpace(l.filter.Value()) != "" {
+		order = "relevance, then " + order
+	}
+	status := fmt.Sprintf("Showing %d of %d repositories - sorted by %s - forks %s - archived %s - private %s",
+		len(l.visible),
+		len(l.repos),
+		order,
+		shownOrHidden(l.showForks),
+		shownOrHidden(l.showArchived),
+		shownOrHidden(l.showPrivate))
+
+	var filter string
+	switch {
+	case l.filtering:
+		filter = l.filter.View()
+	case l.filter.Value() != "":
+		filter = muted.Render("Filter: " + l.filter.Value() + " (esc to clear)")
+	}
+
+	var lines []string
+	lines = append(lines, fit(muted, status, l.width))
+	if filter != "" {
+		lines = append(lines, filter)
+	}
+
+	if len(l.visible) == 0 {
+		lines = append(lines, "", "No repositories match")
+	} else {
+		lines = append(lines, l.table.View())
+		if repo := l.selected(); repo != nil {
+			lines = append(lines, "", fit(lipgloss.NewStyle(), getStringValue(stripControl(repo.Description)), l.width))
+		}
+	}
+
+	help.Width = l.width
+	lines = append(lines, "", help.ShortHelpView([]key.Binding{
+		keys.Up, keys.Down, keys.Filter, keys.Sort, keys.Forks, keys.Archived, keys.Private,
+	}))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func shownOrHidden(shown bool) string {
+	if shown {
+		return "shown"
+	}
+	return "hidden"
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// statusDuration is how long a status message stays in the footer
+const statusDuration = 4 * time.Second
+
+// clearStatusMsg removes a status message unless a newer one replaced it
+type clearStatusMsg struct {
+	id int
+}
+
+// setStatus shows a short-lived message in the footer
+func (m Model) setStatus(text string, isError bool) (Model, tea.Cmd) {
+	m.statusID++
+	m.status = text
+	m.statusError = isError
+
+	id := m.statusID
+	return m, tea.Tick(statusDuration, func(time.Time) tea.Msg {
+		return clearStatusMsg{id: id}
+	})
+}
+
+// renderStatus draws the current status message, if any
+func (m Model) renderStatus() string {
+	if m.status == "" {
+		return ""
+	}
+
+	style := m.theme.accent()
+	if m.statusError {
+		style = m.theme.errorText()
+	}
+	return style.
+		Bold(true).
+		Render(m.status)
+}
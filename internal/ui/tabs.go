@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// App holds one Model per open profile tab and routes messages between them
+type App struct {
+	tabs   []tab
+	active int
+	nextID int
+	keys   keyMap
+
+	// Terminal size, of which the tab bar takes a share once there are several tabs
+	width  int
+	height int
+}
+
+// tab is a profile session with an identity that survives reordering
+type tab struct {
+	id    int
+	model Model
+}
+
+// tabMsg carries a message produced by a tab's command back to that tab
+type tabMsg struct {
+	id  int
+	msg tea.Msg
+}
+
+// NewApp creates a tabbed session whose first tab is first
+func NewApp(first Model) App {
+	return App{
+		tabs:   []tab{{id: 0, model: first}},
+		nextID: 1,
+		keys:   first.keys,
+	}
+}
+
+// Init implements the bubbletea.Model interface
+func (a App) Init() tea.Cmd {
+	return wrapTab(a.tabs[0].id, a.tabs[0].model.Init())
+}
+
+// Update implements the bubbletea.Model interface
+func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tabMsg:
+		for i, t := range a.tabs {
+			if t.id == msg.id {
+				return a.updateTab(i, msg.msg)
+			}
+		}
+		// The tab was closed while its command was running
+		return a, nil
+
+	case tea.WindowSizeMsg:
+		a.width = msg.Width
+		a.height = msg.Height
+		return a.resize()
+
+	case tea.KeyMsg:
+		if a.handlesKey(msg) {
+			return a.handleKey(msg)
+		}
+	}
+
+	return a.updateTab(a.active, msg)
+}
+
+// updateTab passes msg to the tab at index i and tags the commands it returns
+func (a App) updateTab(i int, msg tea.Msg) (App, tea.Cmd) {
+	model, cmd := a.tabs[i].model.Update(msg)
+	a.tabs[i].model = model.(Model)
+	return a, wrapTab(a.tabs[i].id, cmd)
+}
+
+// resize tells every tab how much room it has below the tab bar
+func (a App) resize() (App, tea.Cmd) {
+	if a.width == 0 && a.height == 0 {
+		return a, nil
+	}
+
+	size := tea.WindowSizeMsg{Width: a.width, Height: a.height}
+	if len(a.tabs) > 1 {
+		size.Height -= lipgloss.Height(a.renderTabBar()) + 1
+	}
+
+	var cmds []tea.Cmd
+	for i := range a.tabs {
+		var cmd tea.Cmd
+		a, cmd = a.updateTab(i, size)
+		cmds = append(cmds, cmd)
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// handlesKey reports whether a key press is a tab command. While the active
+// tab is taking text, only non-character keys can switch tabs so every letter
+// still reaches the input.
+func (a App) handlesKey(msg tea.KeyMsg) bool {
+	if !key.Matches(msg, a.keys.NewTab, a.keys.PrevTab, a.keys.NextTab, a.keys.CloseTab) {
+		return false
+	}
+	return !a.tabs[a.active].model.capturesText() || msg.Type != tea.KeyRunes
+}
+
+func (a App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, a.keys.NewTab):
+		return a.openTab()
+
+	case key.Matches(msg, a.keys.PrevTab):
+		a.active = (a.active - 1 + len(a.tabs)) % len(a.tabs)
+
+	case key.Matches(msg, a.keys.NextTab):
+		a.active = (a.active + 1) % len(a.tabs)
+
+	case key.Matches(msg, a.keys.CloseTab):
+		if len(a.tabs) > 1 {
+			a.tabs = append(a.tabs[:a.active], a.tabs[a.active+1:]...)
+			a.active = min(a.active, len(a.tabs)-1)
+			return a.resize()
+		}
+	}
+	return a, nil
+}
+
+// openTab adds a tab on the username prompt and switches to it
+func (a App) openTab() (App, tea.Cmd) {
+	t := tab{id: a.nextID, model: a.tabs[a.active].model.fresh()}
+	a.nextID++
+
+	a.tabs = append(a.tabs, t)
+	a.active = len(a.tabs) - 1
+
+	a, resize := a.resize()
+	return a, tea.Batch(wrapTab(t.id, t.model.Init()), resize)
+}
+
+// View implements the bubbletea.Model interface
+func (a App) View() string {
+	view := a.tabs[a.active].model.View()
+	if len(a.tabs) == 1 {
+		return view
+	}
+	return a.renderTabBar() + "\n\n" + view
+}
+
+// theme returns the theme shared by every tab
+func (a App) theme() Theme {
+	return a.tabs[a.active].model.theme
+}
+
+// renderTabBar lists the open profiles, marking the active one
+func (a App) renderTabBar() string {
+	var labels []string
+	for i, t := range a.tabs {
+		style := a.theme().muted()
+		if i == a.active {
+			style = a.theme().selected()
+		}
+		style = style.Padding(0, 1)
+		labels = append(labels, style.Render(fmt.Sprintf("%d %s", i+1, t.model.title())))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Left, labels...)
+}
+
+// wrapTab tags every message produced by cmd with the tab it belongs to.
+// Batches are unpacked so their commands still run concurrently, and quitting
+// is passed through untouched.
+func wrapTab(id int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.QuitMsg:
+			return msg
+		case tea.BatchMsg:
+			wrapped := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				wrapped[i] = wrapTab(id, c)
+			}
+			return wrapped
+		default:
+			return tabMsg{id: id, msg: msg}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the palette every view draws with
+type Theme struct {
+	Name        string
+	Accent      lipgloss.TerminalColor // titles, headings and the selection background
+	OnAccent    lipgloss.TerminalColor // text drawn on the accent colour
+	Muted       lipgloss.TerminalColor // secondary text
+	Error       lipgloss.TerminalColor
+	Spinner     lipgloss.TerminalColor
+	Highlight   lipgloss.TerminalColor // values that changed and the rank badge
+	OnHighlight lipgloss.TerminalColor
+
+	// Monochrome themes mark selection and highlights with text attributes
+	// instead of colour
+	Monochrome bool
+}
+
+// Themes are the built-in themes by name
+var Themes = map[string]Theme{
+	"dark": {
+		Name:        "dark",
+		Accent:      lipgloss.Color("86"),
+		OnAccent:    lipgloss.Color("0"),
+		Muted:       lipgloss.Color("241"),
+		Error:       lipgloss.Color("196"),
+		Spinner:     lipgloss.Color("205"),
+		Highlight:   lipgloss.Color("226"),
+		OnHighlight: lipgloss.Color("0"),
+	},
+	"light": {
+		Name:        "light",
+		Accent:      lipgloss.Color("30"),
+		OnAccent:    lipgloss.Color("231"),
+		Muted:       lipgloss.Color("243"),
+		Error:       lipgloss.Color("160"),
+		Spinner:     lipgloss.Color("162"),
+		Highlight:   lipgloss.Color("220"),
+		OnHighlight: lipgloss.Color("16"),
+	},
+	"high-contrast": {
+		Name:        "high-contrast",
+		Accent:      lipgloss.Color("14"),
+		OnAccent:    lipgloss.Color("0"),
+		Muted:       lipgloss.Color("15"),
+		Error:       lipgloss.Color("9"),
+		Spinner:     lipgloss.Color("11"),
+		Highlight:   lipgloss.Color("11"),
+		OnHighlight: lipgloss.Color("0"),
+	},
+	"monochrome": {
+		Name:        "monochrome",
+		Accent:      lipgloss.NoColor{},
+		OnAccent:    lipgloss.NoColor{},
+		Muted:       lipgloss.NoColor{},
+		Error:       lipgloss.NoColor{},
+		Spinner:     lipgloss.NoColor{},
+		Highlight:   lipgloss.NoColor{},
+		OnHighlight: lipgloss.NoColor{},
+		Monochrome:  true,
+	},
+}
+
+// ThemeNames lists the accepted theme names, including "auto"
+func ThemeNames() []string {
+	names := []string{"auto"}
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// ResolveTheme returns the named theme. "auto" or an empty name picks
+// monochrome when NO_COLOR is set and otherwise follows the terminal background.
+func ResolveTheme(name string) (Theme, error) {
+	if name == "" || name == "auto" {
+		switch {
+		case os.Getenv("NO_COLOR") != "":
+			return Themes["monochrome"], nil
+		case lipgloss.HasDarkBackground():
+			return Themes["dark"], nil
+		default:
+			return Themes["light"], nil
+		}
+	}
+
+	theme, ok := Themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (valid themes: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	return theme, nil
+}
+
+// heading styles titles and section headings
+func (t Theme) heading() lipgloss.Style {
+	return lipgloss.NewStyle().Bold(true).Foreground(t.Accent)
+}
+
+// accent styles emphasised text that is not a heading
+func (t Theme) accent() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.Accent)
+}
+
+// muted styles secondary text
+func (t Theme) muted() lipgloss.Style {
+	if t.Monochrome {
+		return lipgloss.NewStyle().Faint(true)
+	}
+	return lipgloss.NewStyle().Foreground(t.Muted)
+}
+
+// errorText styles error messages
+func (t Theme) errorText() lipgloss.Style {
+	if t.Monochrome {
+		return lipgloss.NewStyle().Bold(true)
+	}
+	return lipgloss.NewStyle().Foreground(t.Error)
+}
+
+// selected styles the active tab or the row under the cursor
+func (t Theme) selected() lipgloss.Style {
+	if t.Monochrome {
+		return lipgloss.NewStyle().Reverse(true)
+	}
+	return lipgloss.NewStyle().Background(t.Accent).Foreground(t.OnAccent)
+}
+
+// highlighted styles values that deserve attention, such as fresh changes
+func (t Theme) highlighted() lipgloss.Style {
+	if t.Monochrome {
+		return lipgloss.NewStyle().Reverse(true).Bold(true)
+	}
+	return lipgloss.NewStyle().Background(t.Highlight).Foreground(t.OnHighlight)
+}
+
+// border colours the frame of an overlay
+func (t Theme) border() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent)
+}
+
+// helpStyles adapts the key help to the theme
+func (t Theme) helpStyles() help.Styles {
+	styles := help.New().Styles
+	muted := t.muted()
+
+	styles.ShortKey = muted
+	styles.ShortDesc = muted
+	styles.ShortSeparator = muted
+	styles.FullKey = t.accent()
+	styles.FullDesc = muted
+	styles.FullSeparator = muted
+	styles.Ellipsis = muted
+	return styles
+}
@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github-profiler/internal/history"
+	"github-profiler/internal/models"
+)
+
+// maxChangelogEntries bounds the watch changelog so long sessions stay readable
+const maxChangelogEntries = 50
+
+// changelogEntry is a single line in the watch mode changelog
+type changelogEntry struct {
+	At      time.Time
+	Message string
+}
+
+// Watch mode messages
+type watchTickMsg struct {
+	generation int
+}
+
+type ProfileRefreshedMsg struct {
+	Profile *models.UserProfile
+}
+
+type RefreshFailedMsg struct {
+	Error error
+}
+
+type HistoryLoadedMsg struct {
+	History []history.Snapshot
+}
+
+// WithWatch enables periodic background refreshes of the loaded profile
+func (m Model) WithWatch(interval time.Duration) Model {
+	m.watchInterval = interval
+	return m
+}
+
+// watching reports whether watch mode is enabled
+func (m Model) watching() bool {
+	return m.watchInterval > 0
+}
+
+// scheduleWatch starts a new refresh timer. Older timers are invalidated by
+// bumping the generation so a manual refresh never doubles the tick rate.
+func (m Model) scheduleWatch() (Model, tea.Cmd) {
+	if !m.watching() {
+		return m, nil
+	}
+
+	m.watchGeneration++
+	generation := m.watchGeneration
+	return m, tea.Tick(m.watchInterval, func(time.Time) tea.Msg {
+		return watchTickMsg{generation: generation}
+	})
+}
+
+// refreshProfile re-fetches the profile in the background without leaving the profile view
+func (m Model) refreshProfile() tea.Msg {
+	var profile *models.UserProfile
+	var err error
+
+	if m.username == "demo-user" {
+		profile = m.client.DemoProfile()
+	} else {
+		profile, err = m.client.Profile(context.Background(), m.username)
+	}
+
+	if err != nil {
+		return RefreshFailedMsg{Error: err}
+	}
+	return ProfileRefreshedMsg{Profile: profile}
+}
+
+// saveSnapshot persists a refreshed profile that differs from the previous one
+func (m Model) saveSnapshot(profile *models.UserProfile) tea.Cmd {
+	return func() tea.Msg {
+		return HistoryLoadedMsg{History: m.recordSnapshot(profile)}
+	}
+}
+
+// applyRefresh compares a refreshed profile with the current one, records the
+// changes in the changelog and marks the changed values for highlighting
+func (m Model) applyRefresh(profile *models.UserProfile) (Model, bool) {
+	now := time.Now()
+	m.lastRefresh = now
+	m.changed = make(map[string]bool)
+
+	previous := m.profile
+	m.profile = profile
+	if previous == nil {
+		return m, false
+	}
+
+	diff, err := history.Compare([]history.Snapshot{
+		{Login: previous.User.Login, TakenAt: now, Profile: previous},
+		{Login: profile.User.Login, TakenAt: now, Profile: profile},
+	})
+	if err != nil {
+		return m, false
+	}
+
+	var messages []string
+	if diff.Stars.Change != 0 {
+		m.changed["stars"] = true
+		messages = append(messages, fmt.Sprintf("%+d stars (%d total)", diff.Stars.Change, diff.Stars.To))
+	}
+	if diff.Forks.Change != 0 {
+		m.changed["forks"] = true
+		messages = append(messages, fmt.Sprintf("%+d forks (%d total)", diff.Forks.Change, diff.Forks.To))
+	}
+	if diff.Followers.Change != 0 {
+		m.changed["followers"] = true
+		messages = append(messages, fmt.Sprintf("%+d followers (%d total)", diff.Followers.Change, diff.Followers.To))
+	}
+	if diff.PublicRepos.Change != 0 {
+		m.changed["repos"] = true
+		messages = append(messages, fmt.Sprintf("%+d public repos", diff.PublicRepos.Change))
+	}
+	for _, name := range diff.NewRepos {
+		m.changed["repos"] = true
+		messages = append(messages, "new repo "+name)
+	}
+	for _, name := range diff.ArchivedRepos {
+		messages = append(messages, "archived "+name)
+	}
+	if diff.Ranking.FromBadge != diff.Ranking.ToBadge {
+		m.changed["rank"] = true
+		messages = append(messages, fmt.Sprintf("rank %s -> %s", diff.Ranking.FromBadge, diff.Ranking.ToBadge))
+	}
+	if diff.Ranking.TotalScore.Change >= 0.05 || diff.Ranking.TotalScore.Change <= -0.05 {
+		m.changed["score"] = true
+		messages = append(messages, fmt.Sprintf("score %+.1f (%.1f)", diff.Ranking.TotalScore.Change, diff.Ranking.TotalScore.To))
+	}
+
+	for _, message := range messages {
+		m = m.logChange(now, message)
+	}
+
+	return m, len(messages) > 0
+}
+
+// logChange appends a message to the changelog, newest first
+func (m Model) logChange(at time.Time, message string) Model {
+	entries := append([]changelogEntry{{At: at, Message: message}}, m.changelog...)
+	if len(entries) > maxChangelogEntries {
+		entries = entries[:maxChangelogEntries]
+	}
+	m.changelog = entries
+	return m
+}
+
+// highlight renders a value with an attention style when it changed on the last refresh
+func (m Model) highlight(key, value string) string {
+	if !m.changed[key] {
+		return value
+	}
+	return m.theme.highlighted().
+		Bold(true).
+		Render(value)
+}
+
+// renderWatchStatus describes the refresh schedule for the header
+func (m Model) renderWatchStatus() string {
+	status := fmt.Sprintf("Watching every %s", m.watchInterval)
+	if m.refreshing {
+		status += " - refreshing " + m.spinner.View()
+	} else if !m.lastRefresh.IsZero() {
+		status += " - last refresh " + m.lastRefresh.Format("15:04:05")
+	}
+
+	return m.theme.muted().
+		Render(status)
+}
+
+// renderChangelog draws the watch mode side panel
+func (m Model) renderChangelog() string {
+	title := m.theme.heading().
+		Render("Changelog")
+
+	var lines []string
+	if len(m.changelog) == 0 {
+		lines = append(lines, m.theme.muted().
+			Render("No changes yet"))
+	}
+	for _, entry := range m.changelog {
+		lines = append(lines, fmt.Sprintf("%s %s",
+			m.theme.muted().Render(entry.At.Format("15:04")),
+			entry.Message))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1).
+		Width(36).
+		MarginLeft(2).
+		Render(title + "\n\n" + strings.Join(lines, "\n"))
+}
@@ -0,0 +1,672 @@
+// GitHub Profiler web dashboard. Everything is rendered client side from the
+// JSON API served next to this file; no third-party scripts or assets are used.
+"use strict";
+
+// The profile sections, in the order of the TUI views
+const SECTIONS = [
+  { id: "overview", title: "Overview", render: renderOverview },
+  { id: "repositories", title: "Repositories", render: renderRepositories },
+  { id: "languages", title: "Languages", render: renderLanguages },
+  { id: "activity", title: "Activity", render: renderActivity },
+  { id: "ranking", title: "Ranking", render: renderRanking },
+];
+
+const RECENT_KEY = "github-profiler.recent";
+const MAX_RECENT = 20;
+const SVG_NS = "http://www.w3.org/2000/svg";
+
+const app = document.getElementById("app");
+const tooltip = document.getElementById("tooltip");
+
+// Fetched documents by URL, so switching sections does not refetch the profile
+const responses = new Map();
+
+// ---- DOM helpers ----
+
+// h creates an element with attributes and children. Strings become text
+// nodes, so API data is never interpreted as markup.
+function h(tag, attrs, ...children) {
+  return build(document.createElement(tag), attrs, children);
+}
+
+// s is h for SVG elements
+function s(tag, attrs, ...children) {
+  return build(document.createElementNS(SVG_NS, tag), attrs, children);
+}
+
+function build(el, attrs, children) {
+  for (const [name, value] of Object.entries(attrs || {})) {
+    if (value === undefined || value === null || value === false) continue;
+    if (name.startsWith("on")) {
+      el.addEventListener(name.slice(2), value);
+    } else {
+      el.setAttribute(name, value === true ? "" : value);
+    }
+  }
+  for (const child of children.flat()) {
+    if (child === undefined || child === null || child === false) continue;
+    el.append(child instanceof Node ? child : String(child));
+  }
+  return el;
+}
+
+function show(...nodes) {
+  app.replaceChildren(...nodes.flat().filter((node) => node !== undefined && node !== null && node !== false));
+}
+
+// stale reports whether the user navigated away from hash while data was loading
+function stale(hash) {
+  return location.hash !== hash;
+}
+
+function number(n) {
+  return Number(n || 0).toLocaleString();
+}
+
+function fixed(n, digits = 1) {
+  return Number(n || 0).toFixed(digits);
+}
+
+function date(value) {
+  return value ? new Date(value).toLocaleDateString(undefined, { year: "numeric", month: "short", day: "numeric" }) : "-";
+}
+
+// ---- Tooltips ----
+
+// tip shows text next to the pointer while it is over el
+function tip(el, text) {
+  el.addEventListener("mousemove", (event) => {
+    tooltip.textContent = text;
+    tooltip.hidden = false;
+    tooltip.style.left = event.clientX + 12 + "px";
+    tooltip.style.top = event.clientY + 12 + "px";
+  });
+  el.addEventListener("mouseleave", () => {
+    tooltip.hidden = true;
+  });
+  return el;
+}
+
+// ---- Data ----
+
+async function getJSON(url) {
+  if (responses.has(url)) return responses.get(url);
+
+  const response = await fetch(url);
+  const body = await response.json().catch(() => ({}));
+  if (!response.ok) {
+    throw new Error(body.error || response.status + " " + response.statusText);
+  }
+  responses.set(url, body);
+  return body;
+}
+
+// languageColors asks the server for the chart colour of each language
+async function languageColors(names) {
+  if (names.length === 0) return {};
+  const query = names.map((name) => "l=" + encodeURIComponent(name)).join("&");
+  return getJSON("colors.json?" + query).catch(() => ({}));
+}
+
+function sortedLanguages(stats) {
+  return Object.values((stats && stats.languages) || {}).sort(
+    (a, b) => b.percentage - a.percentage || a.name.localeCompare(b.name),
+  );
+}
+
+function recentLogins() {
+  try {
+    return JSON.parse(localStorage.getItem(RECENT_KEY)) || [];
+  } catch {
+    return [];
+  }
+}
+
+function remember(kind, login) {
+  const entry = kind + "/" + login;
+  const recent = recentLogins().filter((e) => e.toLowerCase() !== entry.toLowerCase());
+  recent.unshift(entry);
+  try {
+    localStorage.setItem(RECENT_KEY, JSON.stringify(recent.slice(0, MAX_RECENT)));
+  } catch {
+    // Storage can be disabled; recent logins are a convenience only
+  }
+  fillRecent();
+}
+
+function fillRecent() {
+  const list = document.getElementById("recent-logins");
+  const kind = document.getElementById("search-kind").value;
+  list.replaceChildren(
+    ...recentLogins()
+      .filter((e) => e.startsWith(kind + "/"))
+      .map((e) => h("option", { value: e.slice(kind.length + 1) })),
+  );
+}
+
+// ---- Charts ----
+
+// barChart draws labelled horizontal bars scaled to the largest value
+function barChart(items, { format = number, color = () => "var(--accent)" } = {}) {
+  const max = Math.max(...items.map((item) => item.value), 0);
+  return h(
+    "table",
+    {},
+    items.map((item) =>
+      h(
+        "tr",
+        {},
+        h("td", {}, item.label),
+        h(
+          "td",
+          { style: "width:60%" },
+          tip(
+            h(
+              "div",
+              { class: "bar" },
+              h("span", { style: `width:${max > 0 ? (item.value / max) * 100 : 0}%;background:${color(item)}` }),
+            ),
+            item.label + ": " + format(item.value),
+          ),
+        ),
+        h("td", { class: "num" }, format(item.value)),
+      ),
+    ),
+  );
+}
+
+// columnChart draws one vertical column per item with its label underneath
+function columnChart(items, { height = 160, unit = "" } = {}) {
+  const max = Math.max(...items.map((item) => item.value), 1);
+  const slot = 48;
+  const width = Math.max(items.length * slot, slot);
+
+  const svg = s("svg", { class: "chart", viewBox: `0 -16 ${width} ${height + 36}`, role: "img" });
+  items.forEach((item, i) => {
+    const barHeight = (item.value / max) * height;
+    const x = i * slot + 8;
+    const column = s("rect", {
+      class: "hover",
+      x,
+      y: height - barHeight,
+      width: slot - 16,
+      height: Math.max(barHeight, 1),
+      rx: 3,
+      style: "fill:var(--accent)",
+    });
+    tip(column, `${item.label}: ${number(item.value)}${unit}`);
+    svg.append(
+      column,
+      s("text", { x: x + (slot - 16) / 2, y: height - barHeight - 4, "text-anchor": "middle" }, number(item.value)),
+      s("text", { x: x + (slot - 16) / 2, y: height + 16, "text-anchor": "middle" }, item.label),
+    );
+  });
+  return svg;
+}
+
+// donutChart draws language shares as a ring with a legend. Hovering a slice
+// highlights its legend entry and the other way round.
+function donutChart(languages, colors) {
+  const radius = 70;
+  const stroke = 28;
+  const circumference = 2 * Math.PI * radius;
+
+  const svg = s("svg", { class: "chart", viewBox: "-100 -100 200 200", style: "max-width:260px", role: "img" });
+  const legend = h("ul", { class: "legend" });
+
+  let offset = 0;
+  languages.forEach((lang) => {
+    const length = (lang.percentage / 100) * circumference;
+    const slice = s("circle", {
+      class: "hover",
+      r: radius,
+      fill: "none",
+      style: `stroke:${colors[lang.name] || "var(--accent)"}`,
+      "stroke-width": stroke,
+      "stroke-dasharray": `${length} ${circumference - length}`,
+      "stroke-dashoffset": -offset,
+      transform: "rotate(-90)",
+    });
+    offset += length;
+
+    const entry = h(
+      "li",
+      {},
+      h("span", { class: "swatch", style: `background:${colors[lang.name] || "var(--accent)"}` }),
+      lang.name,
+      h("span", { class: "pct" }, fixed(lang.percentage) + "%"),
+    );
+
+    const activate = (on) => {
+      slice.classList.toggle("active", on);
+      entry.classList.toggle("active", on);
+    };
+    for (const el of [slice, entry]) {
+      el.addEventListener("mouseenter", () => activate(true));
+      el.addEventListener("mouseleave", () => activate(false));
+    }
+    tip(slice, `${lang.name}: ${fixed(lang.percentage)}% (${lang.repo_count} repos)`);
+
+    svg.append(slice);
+    legend.append(entry);
+  });
+
+  return h("div", { class: "columns" }, svg, legend);
+}
+
+// ---- Tables ----
+
+// sortableTable renders rows under columns whose headers sort the table on click
+function sortableTable(columns, rows, { sortBy = 0, descending = true, filter } = {}) {
+  const state = { sortBy, descending, query: "" };
+  const body = h("tbody");
+  const head = h("tr");
+
+  function draw() {
+    const column = columns[state.sortBy];
+    const query = state.query.toLowerCase();
+    const visible = rows
+      .filter((row) => !query || filter(row).toLowerCase().includes(query))
+      .sort((a, b) => {
+        const x = column.sort(a);
+        const y = column.sort(b);
+        const order = typeof x === "string" ? x.localeCompare(y) : x - y;
+        return state.descending ? -order : order;
+      });
+    body.replaceChildren(...visible.map((row) => h("tr", {}, columns.map((c) => h("td", { class: c.num ? "num" : null }, c.cell(row))))));
+    head.replaceChildren(
+      ...columns.map((c, i) =>
+        h(
+          "th",
+          {
+            class: "sortable" + (c.num ? " num" : ""),
+            onclick: () => {
+              state.descending = state.sortBy === i ? !state.descending : true;
+              state.sortBy = i;
+              draw();
+            },
+          },
+          c.title + (state.sortBy === i ? (state.descending ? " ▼" : " ▲") : ""),
+        ),
+      ),
+    );
+  }
+  draw();
+
+  const table = h("table", {}, h("thead", {}, head), body);
+  if (!filter) return table;
+
+  const input = h("input", {
+    class: "filter",
+    type: "search",
+    placeholder: "Filter by name or description",
+    oninput: (event) => {
+      state.query = event.target.value;
+      draw();
+    },
+  });
+  return h("div", {}, input, table);
+}
+
+function repositoryTable(repositories, sortBy = 1) {
+  return sortableTable(
+    [
+      {
+        title: "Name",
+        sort: (r) => r.name.toLowerCase(),
+        cell: (r) => [
+          h("a", { href: r.html_url, target: "_blank", rel: "noopener" }, r.name),
+          r.fork ? h("span", { class: "tag" }, "fork") : null,
+          r.archived ? h("span", { class: "tag" }, "archived") : null,
+          r.description ? h("div", { class: "muted" }, r.description) : null,
+        ],
+      },
+      { title: "Stars", num: true, sort: (r) => r.stargazers_count || 0, cell: (r) => number(r.stargazers_count) },
+      { title: "Forks", num: true, sort: (r) => r.forks_count || 0, cell: (r) => number(r.forks_count) },
+      { title: "Language", sort: (r) => r.language || "", cell: (r) => r.language || "-" },
+      { title: "Updated", sort: (r) => Date.parse(r.updated_at) || 0, cell: (r) => date(r.updated_at) },
+    ],
+    repositories || [],
+    { sortBy, filter: (r) => r.name + " " + (r.description || "") },
+  );
+}
+
+// ---- Pages ----
+
+function renderHome() {
+  const recent = recentLogins();
+  show(
+    h("h1", {}, "Analyze a GitHub profile"),
+    h("p", { class: "muted" }, "Search for a user or organization above, or compare several users side by side."),
+    recent.length > 0 ? h("h2", {}, "Recently viewed") : null,
+    h(
+      "ul",
+      {},
+      recent.map((entry) => {
+        const [kind, login] = entry.split("/");
+        return h("li", {}, h("a", { href: `#/${kind}/${encodeURIComponent(login)}` }, login), " ", h("span", { class: "muted" }, kind === "orgs" ? "organization" : "user"));
+      }),
+    ),
+  );
+}
+
+async function renderUser(login, sectionId) {
+  const hash = location.hash;
+  const section = SECTIONS.find((sec) => sec.id === sectionId) || SECTIONS[0];
+  show(h("p", { class: "loading" }, `Analyzing ${login}… the first visit can take a while for users with many repositories.`));
+
+  let profile;
+  try {
+    profile = await getJSON(`api/v1/users/${encodeURIComponent(login)}`);
+  } catch (err) {
+    if (!stale(hash)) show(h("h1", {}, login), h("p", { class: "error" }, err.message));
+    return;
+  }
+  if (stale(hash)) return;
+  remember("users", profile.user.login);
+
+  const user = profile.user;
+  const tabs = h(
+    "nav",
+    { class: "tabs" },
+    SECTIONS.map((sec) =>
+      h("a", { href: `#/users/${encodeURIComponent(login)}/${sec.id}`, class: sec === section ? "active" : null }, sec.title),
+    ),
+  );
+
+  const content = await section.render(profile);
+  if (stale(hash)) return;
+  show(
+    h("h1", {}, user.name || user.login, " ", h("span", { class: "muted" }, "(" + user.login + ")")),
+    user.bio ? h("p", {}, user.bio) : null,
+    tabs,
+    content,
+  );
+}
+
+function renderOverview(profile) {
+  const user = profile.user;
+  const details = [
+    user.company,
+    user.location,
+    user.blog ? h("a", { href: /^https?:\/\//.test(user.blog) ? user.blog : "https://" + user.blog, target: "_blank", rel: "noopener" }, user.blog) : null,
+    "Joined " + date(user.created_at),
+  ].filter(Boolean);
+
+  const cards = [
+    ["Public Repos", number(user.public_repos)],
+    ["Followers", number(user.followers)],
+    ["Following", number(user.following)],
+    ["Total Stars", number(profile.stats.total_stars)],
+    ["Total Forks", number(profile.stats.total_forks)],
+    ["Repository Size", fixed(profile.stats.total_size_kb / 1024) + " MB"],
+    ["Avg Stars/Repo", fixed(profile.stats.avg_stars_per_repo)],
+    ["Rank", profile.ranking.badge],
+  ];
+
+  return h(
+    "div",
+    {},
+    h("p", { class: "muted" }, details.flatMap((d, i) => (i === 0 ? [d] : [" · ", d]))),
+    h(
+      "div",
+      { class: "grid" },
+      cards.map(([label, value]) => h("div", { class: "card" }, h("div", { class: "muted" }, label), h("div", { class: "value" }, value))),
+    ),
+    h("p", {}, h("a", { href: user.html_url, target: "_blank", rel: "noopener" }, "View on GitHub")),
+  );
+}
+
+function renderRepositories(profile) {
+  return repositoryTable(profile.repositories);
+}
+
+async function renderLanguages(profile) {
+  const languages = sortedLanguages(profile.languages);
+  if (languages.length === 0) {
+    return h("p", { class: "muted" }, "No language data available");
+  }
+  const colors = await languageColors(languages.map((lang) => lang.name));
+
+  return h(
+    "div",
+    {},
+    donutChart(languages, colors),
+    h("h2", {}, "Repositories per language"),
+    barChart(
+      languages.map((lang) => ({ label: lang.name, value: lang.repo_count })),
+      { color: (item) => colors[item.label] || "var(--accent)" },
+    ),
+  );
+}
+
+function renderActivity(profile) {
+  const frequency = profile.stats.update_frequency || {};
+  const buckets = [
+    ["weekly", "Weekly"],
+    ["monthly", "Monthly"],
+    ["quarterly", "Quarterly"],
+    ["yearly", "Yearly"],
+    ["stale", "Stale (>1 year)"],
+  ];
+  const timeline = profile.stats.creation_timeline || [];
+
+  return h(
+    "div",
+    {},
+    h(
+      "div",
+      { class: "grid" },
+      h("div", { class: "card" }, h("div", { class: "muted" }, "Contribution Score"), h("div", { class: "value" }, fixed(profile.activity.contribution_score))),
+      h("div", { class: "card" }, h("div", { class: "muted" }, "Recent Commits"), h("div", { class: "value" }, number(profile.activity.recent_commits))),
+    ),
+    h("h2", {}, "Repository update frequency"),
+    barChart(buckets.map(([key, label]) => ({ label, value: frequency[key] || 0 }))),
+    h("h2", {}, "Repositories created per year"),
+    timeline.length > 0
+      ? columnChart(timeline.map((entry) => ({ label: String(entry.year), value: entry.count })), { unit: " repositories" })
+      : h("p", { class: "muted" }, "No repositories"),
+  );
+}
+
+function renderRanking(profile) {
+  const ranking = profile.ranking;
+  const limits = ranking.max_scores || {};
+  const components = [
+    ["Social", ranking.social_score, limits.social],
+    ["Code", ranking.code_score, limits.code],
+    ["Activity", ranking.activity_score, limits.activity],
+    ["Innovation", ranking.innovation_score, limits.innovation],
+  ];
+
+  const explanation = ranking.explanation;
+  return h(
+    "div",
+    {},
+    h("p", {}, h("span", { class: "badge" }, ranking.badge), " ", ranking.overall_rank),
+    h(
+      "p",
+      {},
+      `Total score ${fixed(ranking.total_score)}/${fixed(limits.total, 0)}`,
+      ranking.percentile_source ? ` · ${fixed(ranking.percentile)} percentile (reference ${ranking.percentile_source})` : "",
+      h("span", { class: "muted" }, ` · scoring model ${ranking.scoring_model}`),
+    ),
+    h("h2", {}, "Score breakdown"),
+    h(
+      "table",
+      {},
+      components.map(([name, score, max]) =>
+        h(
+          "tr",
+          {},
+          h("td", {}, name),
+          h("td", { style: "width:60%" }, tip(h("div", { class: "bar" }, h("span", { style: `width:${max ? (score / max) * 100 : 0}%` })), `${name}: ${fixed(score)} of ${fixed(max, 0)}`)),
+          h("td", { class: "num" }, `${fixed(score)}/${fixed(max, 0)}`),
+        ),
+      ),
+    ),
+    explanation && explanation.hints && explanation.hints.length > 0
+      ? [h("h2", {}, "How to improve"), h("ul", {}, explanation.hints.map((hint) => h("li", {}, hint.message, h("span", { class: "muted" }, ` (+${fixed(hint.gain)})`))))]
+      : null,
+    explanation && explanation.rules && explanation.rules.length > 0
+      ? [
+          h("h2", {}, "Rules"),
+          h(
+            "table",
+            {},
+            explanation.rules.map((rule) =>
+              h("tr", {}, h("td", {}, rule.component), h("td", {}, rule.description, rule.capped ? h("span", { class: "tag" }, "capped") : null), h("td", { class: "num" }, fixed(rule.points))),
+            ),
+          ),
+        ]
+      : null,
+  );
+}
+
+async function renderOrg(login) {
+  const hash = location.hash;
+  show(h("p", { class: "loading" }, `Analyzing ${login}…`));
+
+  let profile;
+  try {
+    profile = await getJSON(`api/v1/orgs/${encodeURIComponent(login)}`);
+  } catch (err) {
+    if (!stale(hash)) show(h("h1", {}, login), h("p", { class: "error" }, err.message));
+    return;
+  }
+  if (stale(hash)) return;
+  const org = profile.organization;
+  remember("orgs", org.login);
+
+  const languages = sortedLanguages(profile.languages);
+  const colors = await languageColors(languages.map((lang) => lang.name));
+  if (stale(hash)) return;
+  const members = profile.members || [];
+
+  show(
+    h("h1", {}, org.name || org.login, " ", h("span", { class: "muted" }, "(" + org.login + ")")),
+    org.description ? h("p", {}, org.description) : null,
+    h("p", { class: "muted" }, [org.location, org.blog, "Created " + date(org.created_at)].filter(Boolean).join(" · ")),
+    h(
+      "div",
+      { class: "grid" },
+      [
+        ["Public Repos", number(org.public_repos)],
+        ["Followers", number(org.followers)],
+        ["Total Stars", number(profile.stats.total_stars)],
+        ["Total Forks", number(profile.stats.total_forks)],
+        ["Public Members", number(members.length)],
+      ].map(([label, value]) => h("div", { class: "card" }, h("div", { class: "muted" }, label), h("div", { class: "value" }, value))),
+    ),
+    h("h2", {}, "Languages"),
+    languages.length > 0 ? donutChart(languages, colors) : h("p", { class: "muted" }, "No language data available"),
+    h("h2", {}, "Repositories created per year"),
+    columnChart((profile.stats.creation_timeline || []).map((entry) => ({ label: String(entry.year), value: entry.count })), { unit: " repositories" }),
+    h("h2", {}, "Repositories"),
+    repositoryTable(profile.repositories),
+    members.length > 0 ? [h("h2", {}, "Public members"), h("p", {}, members.map((m) => h("a", { class: "tag", href: `#/users/${encodeURIComponent(m)}` }, m)))] : null,
+  );
+}
+
+async function renderCompare(logins) {
+  const hash = location.hash;
+  const inputs = h("span", { class: "compare-form" });
+  const addInput = (value = "") => inputs.append(h("input", { type: "search", placeholder: "GitHub login", value }));
+  (logins.length > 0 ? logins : ["", ""]).forEach(addInput);
+
+  const form = h(
+    "form",
+    {
+      class: "compare-form",
+      onsubmit: (event) => {
+        event.preventDefault();
+        const values = [...inputs.querySelectorAll("input")].map((input) => input.value.trim()).filter(Boolean);
+        location.hash = "#/compare?" + values.map((v) => "u=" + encodeURIComponent(v)).join("&");
+      },
+    },
+    inputs,
+    h("button", { type: "button", class: "secondary", onclick: () => addInput() }, "Add user"),
+    h("button", { type: "submit" }, "Compare"),
+  );
+
+  const result = h("div");
+  show(h("h1", {}, "Compare users"), form, result);
+  if (logins.length < 2) {
+    result.append(h("p", { class: "muted" }, "Enter at least two logins."));
+    return;
+  }
+
+  result.append(h("p", { class: "loading" }, "Comparing…"));
+  let comparison;
+  try {
+    comparison = await getJSON("api/v1/compare?" + logins.map((l) => "u=" + encodeURIComponent(l)).join("&"));
+  } catch (err) {
+    if (!stale(hash)) result.replaceChildren(h("p", { class: "error" }, err.message));
+    return;
+  }
+  if (stale(hash)) return;
+
+  const metrics = [
+    ["followers", "Followers", number],
+    ["public_repos", "Public Repos", number],
+    ["total_stars", "Total Stars", number],
+    ["total_forks", "Total Forks", number],
+    ["total_score", "Total Score", fixed],
+  ];
+  const users = comparison.users;
+
+  result.replaceChildren(
+    h(
+      "table",
+      {},
+      h("tr", {}, h("th", {}), users.map((u) => h("th", { class: "num" }, h("a", { href: `#/users/${encodeURIComponent(u.login)}` }, u.login)))),
+      metrics.map(([key, label, format]) =>
+        h("tr", {}, h("td", {}, label), users.map((u) => h("td", { class: "num", style: comparison.leaders[key] === u.login ? "font-weight:600;color:var(--accent)" : null }, format(u[key])))),
+      ),
+      h("tr", {}, h("td", {}, "Rank"), users.map((u) => h("td", { class: "num" }, h("span", { class: "badge" }, u.badge)))),
+      h("tr", {}, h("td", {}, "Top Languages"), users.map((u) => h("td", { class: "num" }, (u.top_languages || []).join(", ") || "-"))),
+    ),
+    h("p", { class: "muted" }, "Shared languages: " + (comparison.shared_languages.join(", ") || "none")),
+    h(
+      "div",
+      { class: "columns" },
+      metrics.map(([key, label, format]) =>
+        h("div", {}, h("h2", {}, label), barChart(users.map((u) => ({ label: u.login, value: u[key] })), { format })),
+      ),
+    ),
+  );
+}
+
+// ---- Routing ----
+
+// route renders the page for the current location hash:
+//   #/users/{login}[/{section}], #/orgs/{login}, #/compare?u=a&u=b
+function route() {
+  tooltip.hidden = true;
+  const [path, query] = location.hash.replace(/^#\/?/, "").split("?");
+  const parts = path.split("/").filter(Boolean).map(decodeURIComponent);
+
+  switch (parts[0]) {
+    case "users":
+      if (parts[1]) return renderUser(parts[1], parts[2]);
+      break;
+    case "orgs":
+      if (parts[1]) return renderOrg(parts[1]);
+      break;
+    case "compare":
+      return renderCompare(new URLSearchParams(query || "").getAll("u"));
+  }
+  renderHome();
+}
+
+document.getElementById("search").addEventListener("submit", (event) => {
+  event.preventDefault();
+  const kind = document.getElementById("search-kind").value;
+  const login = document.getElementById("search-login").value.trim();
+  if (login) location.hash = `#/${kind}/${encodeURIComponent(login)}`;
+});
+document.getElementById("search-kind").addEventListener("change", fillRecent);
+
+window.addEventListener("hashchange", route);
+fillRecent();
+route();
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+
+	"github-profiler/internal/charts"
+)
+
+// static holds the web UI. Every asset is embedded so the dashboard works
+// without network access beyond the GitHub API calls made by the server.
+//
+//go:embed static
+var static embed.FS
+
+// Handler serves the web UI, with api mounted under /api/ for the data it renders
+func Handler(api http.Handler) http.Handler {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", api)
+	mux.Handle("/healthz", api)
+	mux.HandleFunc("/colors.json", handleColors)
+	mux.Handle("/", http.FileServerFS(assets))
+	return mux
+}
+
+// handleColors returns the chart colour of every language named in the l
+// query parameters, so the web charts match the TUI and the SVG card
+func handleColors(w http.ResponseWriter, r *http.Request) {
+	colors := make(map[string]string)
+	for _, name := range r.URL.Query()["l"] {
+		colors[name] = charts.LanguageColor(name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(colors)
+}
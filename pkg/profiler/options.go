@@ -0,0 +1,107 @@
+package profiler
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client
+type Option func(*options)
+
+type options struct {
+	token         string
+	app           *app
+	baseURL       string
+	httpClient    *http.Client
+	cacheTTL      time.Duration
+	cacheDir      string
+	concurrency   int
+	scoringFile   string
+	referenceFile string
+	explain       bool
+}
+
+// WithToken authenticates requests with a GitHub token. Without one only
+// public data is available, under a much lower rate limit.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithApp authenticates as a GitHub App installation instead of with a token.
+// privateKey is the App's private key in PEM format; installation tokens are
+// requested and refreshed automatically.
+func WithApp(appID, installationID int64, privateKey []byte) Option {
+	return func(o *options) {
+		o.app = &app{id: appID, installationID: installationID, privateKey: privateKey}
+	}
+}
+
+type app struct {
+	id             int64
+	installationID int64
+	privateKey     []byte
+}
+
+// WithBaseURL sends API requests to a GitHub Enterprise Server, e.g.
+// https://github.example.com/api/v3/
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sends requests through client's transport and timeout
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithCacheTTL serves profiles and organizations for ttl before fetching them
+// again. Nothing is cached by default.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheTTL = ttl
+	}
+}
+
+// WithCacheDir keeps GitHub responses in dir so later clients can revalidate
+// them with conditional requests, which do not count against the rate limit
+func WithCacheDir(dir string) Option {
+	return func(o *options) {
+		o.cacheDir = dir
+	}
+}
+
+// WithConcurrency sends up to n requests at once when fetching a profile
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// WithScoringFile ranks profiles with the scoring rules in a YAML file
+// instead of the built-in model
+func WithScoringFile(path string) Option {
+	return func(o *options) {
+		o.scoringFile = path
+	}
+}
+
+// WithReferenceFile computes percentiles against the score distribution in a
+// JSON file instead of the bundled one built with the scoring rules, if any
+func WithReferenceFile(path string) Option {
+	return func(o *options) {
+		o.referenceFile = path
+	}
+}
+
+// WithExplanation records in every ranking which rules fired and what would
+// raise the score
+func WithExplanation() Option {
+	return func(o *options) {
+		o.explain = true
+	}
+}
@@ -0,0 +1,161 @@
+// Package profiler analyses GitHub users and organizations: repository and
+// language statistics, activity and a developer ranking.
+//
+//	client, err := profiler.New(profiler.WithToken(token))
+//	if err != nil {
+//		return err
+//	}
+//	profile, err := client.Profile(ctx, "octocat")
+package profiler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github-profiler/internal/cache"
+	"github-profiler/internal/scoring"
+	"github-profiler/internal/services"
+)
+
+// Client fetches and analyses profiles. It is safe for concurrent use.
+type Client struct {
+	service *services.GitHubService
+
+	profiles     *cache.Cache[*Profile]
+	profileCalls cache.Group[*Profile]
+	orgs         *cache.Cache[*Org]
+	orgCalls     cache.Group[*Org]
+}
+
+// New creates a client from opts
+func New(opts ...Option) (*Client, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	serviceOpts := []services.Option{
+		services.WithHTTPClient(o.httpClient),
+		services.WithConcurrency(o.concurrency),
+		services.WithCacheDir(o.cacheDir),
+	}
+
+	if o.baseURL != "" {
+		baseURL, err := url.Parse(o.baseURL)
+		if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+			return nil, fmt.Errorf("invalid base URL %q", o.baseURL)
+		}
+		serviceOpts = append(serviceOpts, services.WithBaseURL(baseURL))
+	}
+
+	if o.app != nil {
+		key, err := services.ParsePrivateKey(o.app.privateKey)
+		if err != nil {
+			return nil, err
+		}
+		serviceOpts = append(serviceOpts, services.WithApp(services.App{
+			ID:             o.app.id,
+			InstallationID: o.app.installationID,
+			PrivateKey:     key,
+		}))
+	}
+
+	if o.scoringFile != "" {
+		model, err := scoring.Load(o.scoringFile)
+		if err != nil {
+			return nil, err
+		}
+		serviceOpts = append(serviceOpts, services.WithScoring(model))
+	}
+
+	if o.referenceFile != "" {
+		dist, err := scoring.LoadDistribution(o.referenceFile)
+		if err != nil {
+			return nil, err
+		}
+		serviceOpts = append(serviceOpts, services.WithReference(dist))
+	}
+
+	if o.explain {
+		serviceOpts = append(serviceOpts, services.WithExplanation())
+	}
+
+	return &Client{
+		service:  services.NewGitHubService(o.token, serviceOpts...),
+		profiles: cache.New[*Profile](o.cacheTTL),
+		orgs:     cache.New[*Org](o.cacheTTL),
+	}, nil
+}
+
+// Profile fetches and analyses a user. Concurrent calls for the same login
+// share one fetch. It is not cancelled with any one caller's ctx, so the others
+// still get its result; each caller stops waiting when its own ctx is done.
+func (c *Client) Profile(ctx context.Context, login string) (*Profile, error) {
+	shared := context.WithoutCancel(ctx)
+	return cache.Fetch(ctx, c.profiles, &c.profileCalls, strings.ToLower(login), func() (*Profile, error) {
+		return c.service.GetUserProfileContext(shared, login)
+	})
+}
+
+// Org fetches and analyses an organization, cached and coalesced like Profile
+func (c *Client) Org(ctx context.Context, login string) (*Org, error) {
+	shared := context.WithoutCancel(ctx)
+	return cache.Fetch(ctx, c.orgs, &c.orgCalls, strings.ToLower(login), func() (*Org, error) {
+		return c.service.GetOrgProfileContext(shared, login)
+	})
+}
+
+// Compare fetches several users at once and summarises them side by side, in
+// the order given
+func (c *Client) Compare(ctx context.Context, logins ...string) (*Comparison, error) {
+	if len(logins) < 2 {
+		return nil, fmt.Errorf("compare needs at least 2 users, got %d", len(logins))
+	}
+
+	profiles := make([]*Profile, len(logins))
+	errs := make([]error, len(logins))
+	var wg sync.WaitGroup
+	for i, login := range logins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profiles[i], errs[i] = c.Profile(ctx, login)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", logins[i], err)
+		}
+	}
+
+	comparison := services.Compare(profiles)
+	return &comparison, nil
+}
+
+// Repository fetches the languages, latest release and README excerpt of a
+// repository. owner is used when repo does not name its owner.
+func (c *Client) Repository(ctx context.Context, repo *Repository, owner string) (*RepositoryDetail, error) {
+	return c.service.GetRepositoryDetailContext(ctx, repo, owner)
+}
+
+// SearchUsers returns up to limit logins matching a partial name, best matches first
+func (c *Client) SearchUsers(ctx context.Context, query string, limit int) ([]string, error) {
+	return c.service.SearchUsersContext(ctx, query, limit)
+}
+
+// DemoProfile returns a sample profile, ranked like a fetched one, without
+// calling GitHub
+func (c *Client) DemoProfile() *Profile {
+	profile, _ := c.service.GetDemoProfile()
+	return profile
+}
+
+// DemoRepository returns sample drill-down data for a repository of the demo profile
+func (c *Client) DemoRepository(repo *Repository) *RepositoryDetail {
+	return services.CreateMockRepositoryDetail(repo)
+}
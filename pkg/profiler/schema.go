@@ -0,0 +1,15 @@
+package profiler
+
+import (
+	"bytes"
+	_ "embed"
+)
+
+//go:embed schema.json
+var schema []byte
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of Profile at
+// SchemaVersion. Its $defs also describe Org and RepositoryDetail.
+func JSONSchema() []byte {
+	return bytes.Clone(schema)
+}
@@ -0,0 +1,37 @@
+package profiler
+
+import (
+	"github-profiler/internal/models"
+)
+
+// SchemaVersion is the version of the result types, also recorded in their
+// schema_version field. Fields may be added within a version; renaming or
+// removing a field bumps it. JSONSchema describes the current version.
+const SchemaVersion = models.SchemaVersion
+
+// Profile is a user's account, repositories and the statistics derived from them
+type Profile = models.UserProfile
+
+// Org is an organization's account, public repositories and statistics
+type Org = models.OrgProfile
+
+// Comparison summarises several profiles side by side
+type Comparison = models.Comparison
+
+// RepositoryDetail is the drill-down data of a single repository
+type RepositoryDetail = models.RepositoryDetail
+
+// Ranking is the developer ranking of a profile
+type Ranking = models.RankingInfo
+
+// User is a GitHub user account
+type User = models.User
+
+// Organization is a GitHub organization account
+type Organization = models.Organization
+
+// Repository is a GitHub repository
+type Repository = models.Repository
+
+// Release is a published repository release
+type Release = models.Release